@@ -0,0 +1,234 @@
+package refl
+
+import (
+	"reflect"
+)
+
+// AsOptions controls advanced behavior of As and AsAll.
+type AsOptions struct {
+	// Embedded enables search in anonymous fields of structures.
+	Embedded bool
+
+	// Unwrap enables following of wrapper methods, see UnwrapMethods.
+	Unwrap bool
+
+	// UnwrapMethods lists names of wrapper methods that have no arguments and a single result,
+	// for example `Unwrap() error`, `Unwrap() []error` or `Unwrap() http.Handler`.
+	// Result that is a slice of interfaces is followed element by element.
+	// Default is "Unwrap" and "Cause".
+	UnwrapMethods []string
+
+	// Convertible enables values that are convertible to the target type and have the same kind,
+	// for example a named string type as target string.
+	Convertible bool
+}
+
+// AsEmbedded enables search in embedded fields.
+func AsEmbedded(o *AsOptions) {
+	o.Embedded = true
+}
+
+// AsUnwrap enables following of wrapper methods.
+func AsUnwrap(o *AsOptions) {
+	o.Unwrap = true
+}
+
+// AsConvertible enables conversion to target type.
+func AsConvertible(o *AsOptions) {
+	o.Convertible = true
+}
+
+// As unwraps interface value to find value assignable to target.
+//
+// Pointers and interfaces are followed by default, options enable
+// search in embedded fields, wrapper methods and convertible types.
+func As(v interface{}, target interface{}, options ...func(o *AsOptions)) bool {
+	rtv := reflect.ValueOf(target)
+	if rtv.Kind() != reflect.Ptr || rtv.IsNil() {
+		panic("target must be a non-nil pointer")
+	}
+
+	found := false
+
+	walkAs(v, rtv.Elem().Type(), options, func(rv reflect.Value) bool {
+		rtv.Elem().Set(rv)
+
+		found = true
+
+		return false
+	})
+
+	return found
+}
+
+// AsAll collects all values that can be assigned to element type of target slice.
+//
+// Target must be a non-nil pointer to a slice, found values are appended to it.
+func AsAll(v interface{}, target interface{}, options ...func(o *AsOptions)) bool {
+	rtv := reflect.ValueOf(target)
+	if rtv.Kind() != reflect.Ptr || rtv.IsNil() || rtv.Elem().Kind() != reflect.Slice {
+		panic("target must be a non-nil pointer to a slice")
+	}
+
+	found := false
+	s := rtv.Elem()
+
+	walkAs(v, s.Type().Elem(), options, func(rv reflect.Value) bool {
+		s.Set(reflect.Append(s, rv))
+
+		found = true
+
+		return true
+	})
+
+	return found
+}
+
+type asWalker struct {
+	opts       AsOptions
+	targetType reflect.Type
+	visited    map[asVisit]bool
+	onMatch    func(rv reflect.Value) bool
+}
+
+type asVisit struct {
+	t   reflect.Type
+	ptr uintptr
+}
+
+func walkAs(v interface{}, targetType reflect.Type, options []func(o *AsOptions), onMatch func(rv reflect.Value) bool) {
+	if v == nil {
+		return
+	}
+
+	w := asWalker{
+		targetType: targetType,
+		visited:    map[asVisit]bool{},
+		onMatch:    onMatch,
+	}
+
+	for _, option := range options {
+		option(&w.opts)
+	}
+
+	if w.opts.UnwrapMethods == nil {
+		w.opts.UnwrapMethods = []string{"Unwrap", "Cause"}
+	}
+
+	w.walk(reflect.ValueOf(v), false, false, 0)
+}
+
+// walk visits value and its descendants, it returns false to stop.
+//
+// Values reached through pointer or interface of a matched value are not matched again.
+func (w *asWalker) walk(rv reflect.Value, viaPtr, matched bool, depth int) bool {
+	if !rv.IsValid() || !rv.CanInterface() || depth > 100 {
+		return true
+	}
+
+	// Nil results of unwrap methods or nil embedded pointers are not matched.
+	if (rv.Kind() == reflect.Interface || rv.Kind() == reflect.Ptr) && rv.IsNil() {
+		return true
+	}
+
+	if !matched {
+		if m, ok := w.match(rv); ok {
+			if !w.onMatch(m) {
+				return false
+			}
+
+			matched = true
+		}
+	}
+
+	switch rv.Kind() { //nolint:exhaustive // Other kinds are not traversed.
+	case reflect.Interface:
+		return w.walk(rv.Elem(), false, matched, depth+1)
+	case reflect.Ptr:
+		k := asVisit{t: rv.Type(), ptr: rv.Pointer()}
+		if w.visited[k] {
+			return true
+		}
+
+		w.visited[k] = true
+
+		if !w.unwrap(rv, depth) {
+			return false
+		}
+
+		return w.walk(rv.Elem(), true, matched, depth+1)
+	}
+
+	if !viaPtr && !w.unwrap(rv, depth) {
+		return false
+	}
+
+	return w.embedded(rv, depth)
+}
+
+func (w *asWalker) match(rv reflect.Value) (reflect.Value, bool) {
+	rt := rv.Type()
+
+	if rt.AssignableTo(w.targetType) {
+		return rv, true
+	}
+
+	if w.opts.Convertible && rt.Kind() == w.targetType.Kind() && rt.ConvertibleTo(w.targetType) {
+		return rv.Convert(w.targetType), true
+	}
+
+	return rv, false
+}
+
+func (w *asWalker) unwrap(rv reflect.Value, depth int) bool {
+	if !w.opts.Unwrap {
+		return true
+	}
+
+	if rv.Kind() != reflect.Ptr && rv.CanAddr() {
+		rv = rv.Addr()
+	}
+
+	for _, name := range w.opts.UnwrapMethods {
+		m := rv.MethodByName(name)
+		if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+			continue
+		}
+
+		res := m.Call(nil)[0]
+
+		if res.Kind() == reflect.Slice && res.Type().Elem().Kind() == reflect.Interface {
+			for i := 0; i < res.Len(); i++ {
+				if !w.walk(res.Index(i), false, false, depth+1) {
+					return false
+				}
+			}
+
+			continue
+		}
+
+		if !w.walk(res, false, false, depth+1) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (w *asWalker) embedded(rv reflect.Value, depth int) bool {
+	if !w.opts.Embedded || rv.Kind() != reflect.Struct {
+		return true
+	}
+
+	for i := 0; i < rv.NumField(); i++ {
+		if !rv.Type().Field(i).Anonymous {
+			continue
+		}
+
+		if !w.walk(rv.Field(i), false, false, depth+1) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,151 @@
+package refl_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/refl"
+)
+
+type (
+	decoratedHandler struct {
+		http.Handler
+	}
+
+	unwrappingHandler struct {
+		h http.Handler
+	}
+
+	namedString string
+
+	codeError struct {
+		code int
+	}
+
+	multiError []error
+)
+
+func (unwrappingHandler) ServeHTTP(http.ResponseWriter, *http.Request) {}
+
+func (u unwrappingHandler) Unwrap() http.Handler {
+	return u.h
+}
+
+func (e codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+func (m multiError) Error() string {
+	return "multi"
+}
+
+func (m multiError) Unwrap() []error {
+	return m
+}
+
+func TestAs_embedded(t *testing.T) {
+	mux := http.NewServeMux()
+
+	var h http.Handler = decoratedHandler{Handler: mux}
+
+	target := new(*http.ServeMux)
+
+	assert.False(t, refl.As(h, target))
+	assert.True(t, refl.As(h, target, refl.AsEmbedded))
+	assert.Equal(t, mux, *target)
+
+	h = &decoratedHandler{Handler: unwrappingHandler{h: mux}}
+	*target = nil
+
+	assert.False(t, refl.As(h, target, refl.AsEmbedded))
+	assert.True(t, refl.As(h, target, refl.AsEmbedded, refl.AsUnwrap))
+	assert.Equal(t, mux, *target)
+}
+
+func TestAs_unwrap(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", multiError{io.EOF, codeError{code: 404}})
+
+	target := new(codeError)
+
+	assert.False(t, refl.As(err, target))
+	assert.True(t, refl.As(err, target, refl.AsUnwrap))
+	assert.Equal(t, 404, target.code)
+
+	var all []error
+
+	assert.True(t, refl.AsAll(err, &all, refl.AsUnwrap))
+	assert.Len(t, all, 4)
+	assert.Equal(t, err, all[0])
+	assert.Equal(t, io.EOF, all[2])
+	assert.Equal(t, codeError{code: 404}, all[3])
+
+	code := new(codeError)
+
+	assert.True(t, refl.As(all[1], code, refl.AsUnwrap))
+	assert.Equal(t, 404, code.code)
+}
+
+type nilUnwrapper struct{}
+
+func (nilUnwrapper) Unwrap() error { return nil }
+
+type nilUnwrapError struct {
+	nilUnwrapper
+}
+
+func (nilUnwrapError) Error() string { return "nil unwrap" }
+
+func TestAs_nilUnwrap(t *testing.T) {
+	var all []error
+
+	assert.True(t, refl.AsAll(nilUnwrapError{}, &all, refl.AsUnwrap))
+	assert.Equal(t, []error{nilUnwrapError{}}, all)
+
+	var target error
+
+	assert.False(t, refl.As(nilUnwrapper{}, &target, refl.AsUnwrap))
+	assert.Nil(t, target)
+}
+
+func TestAs_convertible(t *testing.T) {
+	var v interface{} = namedString("abc")
+
+	s := new(string)
+
+	assert.False(t, refl.As(v, s))
+	assert.True(t, refl.As(v, s, refl.AsConvertible))
+	assert.Equal(t, "abc", *s)
+
+	assert.False(t, refl.As(123, s, refl.AsConvertible))
+}
+
+func TestAsAll(t *testing.T) {
+	type Label string
+
+	var names []string
+
+	v := struct {
+		Label
+		Inner  struct{ Label }
+		hidden struct{ Label }
+	}{Label: "foo"}
+
+	v.Inner.Label = "bar"
+	v.hidden.Label = "baz"
+
+	assert.True(t, refl.AsAll(&v, &names, refl.AsEmbedded, refl.AsConvertible))
+	assert.Equal(t, []string{"foo"}, names)
+
+	names = nil
+
+	assert.True(t, refl.AsAll(v.Inner, &names, refl.AsEmbedded, refl.AsConvertible))
+	assert.Equal(t, []string{"bar"}, names)
+
+	assert.False(t, refl.AsAll(nil, &names))
+	assert.Panics(t, func() {
+		refl.AsAll(v, names)
+	})
+}
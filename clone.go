@@ -0,0 +1,250 @@
+package refl
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+// CloneOptions controls advanced behavior of Clone.
+type CloneOptions struct {
+	// TagName is a name of field tag that controls cloning, default "clone".
+	//
+	// Tag value "-" leaves zero value in the clone, "shallow" copies the value without descending into it.
+	TagName string
+
+	// Hooks are custom cloning functions for exact types, they take precedence over default behavior.
+	//
+	//	o.Hooks = map[reflect.Type]func(v interface{}) interface{}{
+	//		reflect.TypeOf(new(os.File)): func(v interface{}) interface{} { return v },
+	//	}
+	Hooks map[reflect.Type]func(v interface{}) interface{}
+}
+
+var shallowTypes = map[reflect.Type]bool{
+	reflect.TypeOf(time.Time{}):        true,
+	reflect.TypeOf(new(time.Location)): true,
+}
+
+// Clone returns a deep copy of a value.
+//
+// Structures (including unexported fields), pointers, slices, arrays, maps and interfaces are copied recursively.
+// Pointers and maps that are shared in the source, are also shared in the clone, so cyclic
+// structures are supported. Slices are shared in the clone only if they have the same start and length
+// in the source, overlapping slices (for example s and s[:2]) are cloned into independent backing arrays.
+// Channels, functions and unsafe pointers are copied as is, same for time.Time.
+//
+// Nested values that have `Clone() T` method with T being own type of value are cloned with that method.
+func Clone(v interface{}, options ...func(o *CloneOptions)) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	c := cloner{
+		seen: map[cloneKey]reflect.Value{},
+	}
+
+	for _, option := range options {
+		option(&c.opts)
+	}
+
+	if c.opts.TagName == "" {
+		c.opts.TagName = "clone"
+	}
+
+	src := reflect.ValueOf(v)
+	dst := reflect.New(src.Type()).Elem()
+
+	c.clone(dst, src, true)
+
+	return dst.Interface()
+}
+
+type cloneKey struct {
+	t   reflect.Type
+	ptr uintptr
+	len int
+}
+
+type cloner struct {
+	opts CloneOptions
+	seen map[cloneKey]reflect.Value
+}
+
+// clone copies src into settable dst.
+func (c *cloner) clone(dst, src reflect.Value, root bool) {
+	t := src.Type()
+
+	if hook, ok := c.opts.Hooks[t]; ok {
+		if res := hook(src.Interface()); res != nil {
+			dst.Set(reflect.ValueOf(res))
+		}
+
+		return
+	}
+
+	if shallowTypes[t] {
+		dst.Set(src)
+
+		return
+	}
+
+	if !root && c.cloneMethod(dst, src) {
+		return
+	}
+
+	switch t.Kind() { //nolint:exhaustive // Scalars are copied by default.
+	case reflect.Ptr:
+		c.clonePtr(dst, src)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+
+		e := src.Elem()
+		n := reflect.New(e.Type()).Elem()
+		c.clone(n, e, false)
+		dst.Set(n)
+	case reflect.Struct:
+		c.cloneStruct(dst, src)
+	case reflect.Slice:
+		c.cloneSlice(dst, src)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			c.clone(dst.Index(i), src.Index(i), false)
+		}
+	case reflect.Map:
+		c.cloneMap(dst, src)
+	default:
+		dst.Set(src)
+	}
+}
+
+// cloneMethod uses `Clone() T` method if it is available.
+func (c *cloner) cloneMethod(dst, src reflect.Value) bool {
+	if src.Kind() == reflect.Ptr && src.IsNil() {
+		return false
+	}
+
+	m := src.MethodByName("Clone")
+	if !m.IsValid() {
+		return false
+	}
+
+	mt := m.Type()
+	if mt.NumIn() != 0 || mt.NumOut() != 1 || mt.Out(0) != src.Type() {
+		return false
+	}
+
+	dst.Set(m.Call(nil)[0])
+
+	return true
+}
+
+func (c *cloner) clonePtr(dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+
+	k := cloneKey{t: src.Type(), ptr: src.Pointer()}
+	if n, ok := c.seen[k]; ok {
+		dst.Set(n)
+
+		return
+	}
+
+	n := reflect.New(src.Type().Elem())
+	c.seen[k] = n
+
+	c.clone(n.Elem(), src.Elem(), false)
+	dst.Set(n)
+}
+
+func (c *cloner) cloneStruct(dst, src reflect.Value) {
+	t := src.Type()
+
+	if !src.CanAddr() {
+		tmp := reflect.New(t).Elem()
+		tmp.Set(src)
+		src = tmp
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag := strings.Split(sf.Tag.Get(c.opts.TagName), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		df := dst.Field(i)
+		sv := src.Field(i)
+
+		if sf.PkgPath != "" {
+			df = reflect.NewAt(sf.Type, unsafe.Pointer(df.UnsafeAddr())).Elem() //nolint:gosec // Access to unexported field.
+			sv = reflect.NewAt(sf.Type, unsafe.Pointer(sv.UnsafeAddr())).Elem() //nolint:gosec // Access to unexported field.
+		}
+
+		if tag == "shallow" {
+			df.Set(sv)
+
+			continue
+		}
+
+		c.clone(df, sv, false)
+	}
+}
+
+func (c *cloner) cloneSlice(dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+
+	k := cloneKey{t: src.Type(), ptr: src.Pointer(), len: src.Len()}
+	if n, ok := c.seen[k]; ok {
+		dst.Set(n)
+
+		return
+	}
+
+	n := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+	c.seen[k] = n
+
+	for i := 0; i < src.Len(); i++ {
+		c.clone(n.Index(i), src.Index(i), false)
+	}
+
+	dst.Set(n)
+}
+
+func (c *cloner) cloneMap(dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+
+	t := src.Type()
+
+	k := cloneKey{t: t, ptr: src.Pointer()}
+	if n, ok := c.seen[k]; ok {
+		dst.Set(n)
+
+		return
+	}
+
+	n := reflect.MakeMapWithSize(t, src.Len())
+	c.seen[k] = n
+
+	iter := src.MapRange()
+	for iter.Next() {
+		key := reflect.New(t.Key()).Elem()
+		c.clone(key, iter.Key(), false)
+
+		val := reflect.New(t.Elem()).Elem()
+		c.clone(val, iter.Value(), false)
+
+		n.SetMapIndex(key, val)
+	}
+
+	dst.Set(n)
+}
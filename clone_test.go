@@ -0,0 +1,119 @@
+package refl_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+	"github.com/swaggest/refl/internal/sample"
+)
+
+type cloneNode struct {
+	Name     string
+	Next     *cloneNode
+	Children []*cloneNode
+	Meta     map[string]interface{}
+	Tags     [2]string
+	private  []int
+	At       time.Time
+}
+
+type cloneConn struct {
+	ID int
+}
+
+type cloneMethod struct {
+	Value  int
+	Cloned bool
+}
+
+func (c cloneMethod) Clone() cloneMethod {
+	return cloneMethod{Value: c.Value, Cloned: true}
+}
+
+type cloneHolder struct {
+	mu      sync.Mutex `clone:"-"`
+	Conn    *cloneConn `clone:"shallow"`
+	Method  cloneMethod
+	Methods []*cloneMethod
+	Any     interface{}
+}
+
+func TestClone(t *testing.T) {
+	a := &cloneNode{Name: "a", Meta: map[string]interface{}{"k": []int{1, 2}}, Tags: [2]string{"x", "y"}}
+	b := &cloneNode{Name: "b", Next: a, private: []int{3}, At: time.Now()}
+	a.Next = b
+	a.Children = []*cloneNode{b, b}
+
+	c, ok := refl.Clone(a).(*cloneNode)
+	require.True(t, ok)
+
+	assert.True(t, a != c)
+	assert.Equal(t, "a", c.Name)
+	assert.True(t, b != c.Next)
+	assert.Same(t, c, c.Next.Next)
+	assert.Same(t, c.Next, c.Children[0])
+	assert.Same(t, c.Children[0], c.Children[1])
+	assert.Equal(t, []int{3}, c.Next.private)
+	assert.True(t, b.At.Equal(c.Next.At))
+	assert.Equal(t, [2]string{"x", "y"}, c.Tags)
+
+	c.Meta["k"].([]int)[0] = 100
+	c.Next.private[0] = 300
+
+	assert.Equal(t, []int{1, 2}, a.Meta["k"])
+	assert.Equal(t, []int{3}, b.private)
+}
+
+func TestClone_slices(t *testing.T) {
+	type pair struct {
+		A, B, C []int
+	}
+
+	s := []int{1, 2, 3}
+	c, ok := refl.Clone(pair{A: s, B: s, C: s[:2]}).(pair)
+	require.True(t, ok)
+
+	c.A[0] = 10
+	assert.Equal(t, []int{10, 2, 3}, c.B)
+	assert.Equal(t, []int{1, 2}, c.C)
+	assert.Equal(t, []int{1, 2, 3}, s)
+}
+
+func TestClone_tagsAndHooks(t *testing.T) {
+	conn := &cloneConn{ID: 1}
+	h := cloneHolder{
+		Conn:    conn,
+		Method:  cloneMethod{Value: 1},
+		Methods: []*cloneMethod{{Value: 2}},
+		Any:     sample.TestSubStruct{SubInt: 3},
+	}
+	h.mu.Lock()
+
+	c, ok := refl.Clone(&h).(*cloneHolder)
+	require.True(t, ok)
+
+	assert.True(t, reflect.ValueOf(c).Elem().FieldByName("mu").IsZero())
+	assert.Same(t, conn, c.Conn)
+	assert.Equal(t, cloneMethod{Value: 1, Cloned: true}, c.Method)
+	assert.Equal(t, &cloneMethod{Value: 2, Cloned: true}, c.Methods[0])
+	assert.True(t, h.Methods[0] != c.Methods[0])
+	assert.Equal(t, sample.TestSubStruct{SubInt: 3}, c.Any)
+
+	c, ok = refl.Clone(&h, func(o *refl.CloneOptions) {
+		o.Hooks = map[reflect.Type]func(v interface{}) interface{}{
+			reflect.TypeOf(new(cloneMethod)): func(v interface{}) interface{} {
+				return &cloneMethod{Value: v.(*cloneMethod).Value * 10}
+			},
+		}
+	}).(*cloneHolder)
+	require.True(t, ok)
+
+	assert.Equal(t, 20, c.Methods[0].Value)
+	assert.Nil(t, refl.Clone(nil))
+	assert.Equal(t, cloneMethod{Value: 5}, refl.Clone(cloneMethod{Value: 5}))
+}
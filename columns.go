@@ -0,0 +1,302 @@
+package refl
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+var (
+	sqlScanner   = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	driverValuer = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// ColumnsOptions controls advanced behavior of Columns and FieldPointers.
+type ColumnsOptions struct {
+	// Separator is put between tag name of nested structure and its column names, default ".".
+	Separator string
+
+	// SkipUnknown makes FieldPointers discard values of unknown columns instead of failing.
+	SkipUnknown bool
+}
+
+func columnsOptions(options []func(o *ColumnsOptions)) ColumnsOptions {
+	opts := ColumnsOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	opts.setDefaults()
+
+	return opts
+}
+
+func (o *ColumnsOptions) setDefaults() {
+	if o.Separator == "" {
+		o.Separator = "."
+	}
+}
+
+// isColumn checks if type is stored in a single column.
+func isColumn(t reflect.Type) bool {
+	t = DeepIndirect(t)
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+
+	pt := reflect.PtrTo(t)
+
+	return pt.Implements(sqlScanner) || t.Implements(driverValuer) || pt.Implements(driverValuer) || isMarshaler(t)
+}
+
+type columnsWalker struct {
+	tagName  string
+	opts     ColumnsOptions
+	alloc    bool
+	visiting map[reflect.Type]bool
+
+	// null is set for fields of nil nested structures that are not allocated,
+	// their values are passed to callback as nil pointers.
+	null bool
+}
+
+func (w columnsWalker) walk(v reflect.Value, prefix string, f func(column string, fv reflect.Value, sf reflect.StructField)) {
+	if w.alloc {
+		allocEmbedded(v)
+	}
+
+	WalkTaggedFields(v, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		column := prefix + tag
+
+		if isColumn(sf.Type) {
+			if w.null {
+				fv = reflect.Zero(reflect.PtrTo(sf.Type))
+			}
+
+			f(column, fv, sf)
+
+			return
+		}
+
+		st := DeepIndirect(sf.Type)
+		if w.visiting[st] {
+			return
+		}
+
+		nw := w
+
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				if !w.alloc || !fv.CanSet() {
+					fv = reflect.New(sf.Type.Elem())
+					nw.null = true
+				} else {
+					fv.Set(reflect.New(sf.Type.Elem()))
+				}
+			}
+
+			fv = fv.Elem()
+		}
+
+		w.visiting[st] = true
+		nw.walk(fv, column+w.opts.Separator, f)
+		delete(w.visiting, st)
+	}, w.tagName)
+}
+
+// Columns returns tag names of structure (or a pointer to it) fields in order of declaration.
+//
+// Embedded structures are flattened, columns of nested structures are prefixed with tag name of
+// the structure field, for example "address.city". Structures that implement sql.Scanner,
+// driver.Valuer or encoding.TextMarshaler (for example time.Time) are single columns.
+// Fields of recursive structure types are skipped.
+func Columns(v interface{}, tagName string, options ...func(o *ColumnsOptions)) []string {
+	var res []string
+
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil
+	}
+
+	w := columnsWalker{tagName: tagName, opts: columnsOptions(options), visiting: map[reflect.Type]bool{}}
+	w.visiting[DeepIndirect(rv.Type())] = true
+
+	w.walk(rv, "", func(column string, _ reflect.Value, _ reflect.StructField) {
+		res = append(res, column)
+	})
+
+	return res
+}
+
+// FieldPointers returns pointers to structure fields in order of columns, for example to use with sql.Rows Scan.
+//
+// Column names are resolved as in Columns, nil pointers of nested structures are allocated.
+// Unknown columns are reported with ErrUnknownColumn unless SkipUnknown option is enabled.
+//
+//	cols, _ := rows.Columns()
+//	ptrs, err := refl.FieldPointers(&row, "db", cols)
+//	...
+//	err = rows.Scan(ptrs...)
+func FieldPointers(structPtr interface{}, tagName string, columns []string, options ...func(o *ColumnsOptions)) ([]interface{}, error) {
+	v := reflect.ValueOf(structPtr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, ErrNeedPointer
+	}
+
+	if v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w, %s received", ErrStructExpected, v.Elem().Type().String())
+	}
+
+	opts := columnsOptions(options)
+	w := columnsWalker{tagName: tagName, opts: opts, alloc: true, visiting: map[reflect.Type]bool{}}
+	w.visiting[v.Elem().Type()] = true
+
+	pointers := map[string]interface{}{}
+
+	w.walk(v.Elem(), "", func(column string, fv reflect.Value, _ reflect.StructField) {
+		if _, ok := pointers[column]; ok || !fv.CanAddr() || !fv.CanInterface() {
+			return
+		}
+
+		pointers[column] = fv.Addr().Interface()
+	})
+
+	res := make([]interface{}, 0, len(columns))
+
+	var errs []error
+
+	for _, c := range columns {
+		p, ok := pointers[c]
+		if !ok {
+			if !opts.SkipUnknown {
+				errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownColumn, c))
+			}
+
+			p = new(interface{})
+		}
+
+		res = append(res, p)
+	}
+
+	if err := JoinErrors(errs...); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ColumnValuesOptions controls advanced behavior of ColumnValues.
+type ColumnValuesOptions struct {
+	ColumnsOptions
+
+	// SkipZero skips columns with zero values.
+	SkipZero bool
+
+	// SkipPK skips columns tagged with "pk" option, for example to build SET clause of UPDATE
+	// or INSERT with auto-incremented key.
+	SkipPK bool
+}
+
+// ColumnValues returns parallel lists of columns and values of structure (or a pointer to it) fields,
+// for example to build INSERT or UPDATE statements.
+//
+// Columns are resolved as in Columns. Tag options control which columns are returned:
+//   - "omitempty" skips zero value,
+//   - "readonly" always skips the column, for example if it is maintained by database,
+//   - "pk" marks primary key, it is skipped with SkipPK option.
+//
+// Values that implement driver.Valuer are replaced with the result of Value.
+// Columns of nil nested structure pointers have nil values, so they are skipped as zero values
+// with "omitempty" option or SkipZero.
+//
+//	type Row struct {
+//		ID        int       `db:"id,pk"`
+//		Name      string    `db:"name"`
+//		Note      string    `db:"note,omitempty"`
+//		CreatedAt time.Time `db:"created_at,readonly"`
+//	}
+func ColumnValues(v interface{}, tagName string, options ...func(o *ColumnValuesOptions)) ([]string, []interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return nil, nil, fmt.Errorf("%w, nil received", ErrStructExpected)
+	}
+
+	if DeepIndirect(rv.Type()).Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("%w, %s received", ErrStructExpected, rv.Type().String())
+	}
+
+	opts := ColumnValuesOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	opts.setDefaults()
+
+	w := columnsWalker{tagName: tagName, opts: opts.ColumnsOptions, visiting: map[reflect.Type]bool{}}
+	w.visiting[DeepIndirect(rv.Type())] = true
+
+	var (
+		columns []string
+		values  []interface{}
+		errs    []error
+	)
+
+	w.walk(rv, "", func(column string, fv reflect.Value, sf reflect.StructField) {
+		tag := sf.Tag.Get(tagName)
+
+		if !fv.CanInterface() || hasTagOption(tag, "readonly") || (opts.SkipPK && hasTagOption(tag, "pk")) {
+			return
+		}
+
+		if (opts.SkipZero || hasTagOption(tag, "omitempty")) && isZero(fv) {
+			return
+		}
+
+		val, err := columnValue(fv)
+		if err != nil {
+			errs = append(errs, fieldErr(column, tagName, fv, fv.Type(), err))
+
+			return
+		}
+
+		columns = append(columns, column)
+		values = append(values, val)
+	})
+
+	if err := JoinErrors(errs...); err != nil {
+		return nil, nil, err
+	}
+
+	return columns, values, nil
+}
+
+func columnValue(v reflect.Value) (interface{}, error) {
+	if v.Type().Implements(driverValuer) {
+		if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+			return nil, nil
+		}
+
+		return v.Interface().(driver.Valuer).Value() //nolint:forcetypeassert // Implementation is checked.
+	}
+
+	if reflect.PtrTo(v.Type()).Implements(driverValuer) {
+		if !v.CanAddr() {
+			tmp := reflect.New(v.Type()).Elem()
+			tmp.Set(v)
+			v = tmp
+		}
+
+		return v.Addr().Interface().(driver.Valuer).Value() //nolint:forcetypeassert // Implementation is checked.
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+
+		return columnValue(v.Elem())
+	}
+
+	return v.Interface(), nil
+}
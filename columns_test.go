@@ -0,0 +1,164 @@
+package refl_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type columnsAddress struct {
+	City   string `db:"city"`
+	Street string `db:"street"`
+}
+
+type columnsBase struct {
+	ID int `db:"id"`
+}
+
+type columnsRow struct {
+	columnsBase
+	Name      sql.NullString  `db:"name"`
+	CreatedAt time.Time       `db:"created_at"`
+	Address   columnsAddress  `db:"address"`
+	Billing   *columnsAddress `db:"billing"`
+	Parent    *columnsRow     `db:"parent"`
+	Data      []byte          `db:"data"`
+	Skipped   string          `db:"-"`
+	Untagged  string
+}
+
+func TestColumns(t *testing.T) {
+	cols := []string{
+		"id", "name", "created_at", "address.city", "address.street",
+		"billing.city", "billing.street", "data",
+	}
+
+	assert.Equal(t, cols, refl.Columns(columnsRow{}, "db"))
+	assert.Equal(t, cols, refl.Columns((*columnsRow)(nil), "db"))
+	assert.Equal(t, []string{"id", "name", "created_at", "address_city", "address_street",
+		"billing_city", "billing_street", "data"}, refl.Columns(&columnsRow{}, "db", func(o *refl.ColumnsOptions) {
+		o.Separator = "_"
+	}))
+}
+
+func TestFieldPointers(t *testing.T) {
+	var row columnsRow
+
+	ptrs, err := refl.FieldPointers(&row, "db", []string{"billing.city", "id", "name"})
+	require.NoError(t, err)
+	require.Len(t, ptrs, 3)
+
+	require.NotNil(t, row.Billing)
+	assert.Equal(t, &row.Billing.City, ptrs[0])
+	assert.Equal(t, &row.ID, ptrs[1])
+	assert.Equal(t, &row.Name, ptrs[2])
+
+	*(ptrs[1].(*int)) = 12
+	require.NoError(t, ptrs[2].(sql.Scanner).Scan("foo"))
+
+	assert.Equal(t, 12, row.ID)
+	assert.Equal(t, "foo", row.Name.String)
+
+	_, err = refl.FieldPointers(&row, "db", []string{"id", "foo", "bar"})
+	assert.True(t, errors.Is(err, refl.ErrUnknownColumn))
+	assert.EqualError(t, err, "unknown column: foo, unknown column: bar")
+
+	ptrs, err = refl.FieldPointers(&row, "db", []string{"id", "foo"}, func(o *refl.ColumnsOptions) {
+		o.SkipUnknown = true
+	})
+	require.NoError(t, err)
+	assert.Len(t, ptrs, 2)
+	assert.IsType(t, new(interface{}), ptrs[1])
+
+	_, err = refl.FieldPointers(row, "db", nil)
+	assert.Equal(t, refl.ErrNeedPointer, err)
+}
+
+type columnsMoney int64
+
+func (m columnsMoney) Value() (driver.Value, error) {
+	if m < 0 {
+		return nil, errors.New("negative amount")
+	}
+
+	return fmt.Sprintf("%d.%02d", m/100, m%100), nil
+}
+
+type columnsTags []string
+
+func (t *columnsTags) Value() (driver.Value, error) {
+	return strings.Join(*t, ","), nil
+}
+
+type columnsValuesRow struct {
+	ID        int             `db:"id,pk"`
+	Name      string          `db:"name"`
+	Note      string          `db:"note,omitempty"`
+	Price     columnsMoney    `db:"price"`
+	Discount  *columnsMoney   `db:"discount"`
+	Tags      columnsTags     `db:"tags"`
+	Parent    *int            `db:"parent_id"`
+	Address   columnsAddress  `db:"address"`
+	Billing   *columnsAddress `db:"billing"`
+	Extra     driver.Valuer   `db:"extra"`
+	CreatedAt time.Time       `db:"created_at,readonly"`
+}
+
+func TestColumnValues(t *testing.T) {
+	parent := 3
+	row := columnsValuesRow{
+		ID:      1,
+		Name:    "foo",
+		Price:   1250,
+		Tags:    columnsTags{"a", "b"},
+		Parent:  &parent,
+		Address: columnsAddress{City: "Berlin"},
+	}
+
+	cols, vals, err := refl.ColumnValues(&row, "db")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"id", "name", "price", "discount", "tags", "parent_id", "address.city", "address.street",
+		"billing.city", "billing.street", "extra",
+	}, cols)
+	assert.Equal(t, []interface{}{1, "foo", "12.50", nil, "a,b", 3, "Berlin", "", nil, nil, nil}, vals)
+
+	for _, v := range vals {
+		_, err := driver.DefaultParameterConverter.ConvertValue(v)
+		assert.NoError(t, err)
+	}
+
+	cols, vals, err = refl.ColumnValues(row, "db", func(o *refl.ColumnValuesOptions) {
+		o.SkipPK = true
+		o.SkipZero = true
+		o.Separator = "_"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "price", "tags", "parent_id", "address_city"}, cols)
+	assert.Equal(t, []interface{}{"foo", "12.50", "a,b", 3, "Berlin"}, vals)
+
+	row.Billing = &columnsAddress{Street: "Main"}
+	row.Extra = columnsMoney(5)
+
+	cols, vals, err = refl.ColumnValues(row, "db", func(o *refl.ColumnValuesOptions) {
+		o.SkipZero = true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "name", "price", "tags", "parent_id", "address.city", "billing.street", "extra"}, cols)
+	assert.Equal(t, []interface{}{1, "foo", "12.50", "a,b", 3, "Berlin", "Main", "0.05"}, vals)
+
+	row.Price = -1
+	_, _, err = refl.ColumnValues(row, "db")
+	assert.EqualError(t, err, "price: negative amount")
+
+	_, _, err = refl.ColumnValues(1, "db")
+	assert.True(t, errors.Is(err, refl.ErrStructExpected))
+}
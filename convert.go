@@ -0,0 +1,396 @@
+package refl
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// ConvertOptions controls advanced behavior of Convert.
+type ConvertOptions struct {
+	// TimeLayout is used to parse and format time.Time, default time.RFC3339Nano.
+	TimeLayout string
+}
+
+// Convert converts src value into a value pointed by dstPtr.
+//
+// Supported conversions:
+//   - between numeric kinds with overflow checks, floats with fractional part are not converted to integers,
+//   - between strings and numbers, booleans, time.Duration and time.Time (with configurable layout),
+//   - from strings to encoding.TextUnmarshaler and from encoding.TextMarshaler to strings,
+//   - between named types and their underlying types,
+//   - between slices and arrays element by element,
+//   - from pointers (nil pointer results in zero value) and into pointers (new value is allocated).
+func Convert(src interface{}, dstPtr interface{}, options ...func(o *ConvertOptions)) error {
+	dst := reflect.ValueOf(dstPtr)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return ErrNeedPointer
+	}
+
+	opts := ConvertOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	return newConverter(opts).convert(dst.Elem(), reflect.ValueOf(src))
+}
+
+type converter struct {
+	opts ConvertOptions
+}
+
+func newConverter(opts ConvertOptions) converter {
+	if opts.TimeLayout == "" {
+		opts.TimeLayout = time.RFC3339Nano
+	}
+
+	return converter{opts: opts}
+}
+
+// convert sets settable dst from src.
+func (c converter) convert(dst, src reflect.Value) error {
+	for src.IsValid() && !src.Type().AssignableTo(dst.Type()) &&
+		(src.Kind() == reflect.Ptr || src.Kind() == reflect.Interface) {
+		if src.IsNil() {
+			src = reflect.Value{}
+
+			break
+		}
+
+		src = src.Elem()
+	}
+
+	if !src.IsValid() {
+		dst.Set(reflect.Zero(dst.Type()))
+
+		return nil
+	}
+
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+
+		return nil
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		n := reflect.New(dst.Type().Elem())
+		if err := c.convert(n.Elem(), src); err != nil {
+			return err
+		}
+
+		dst.Set(n)
+
+		return nil
+	}
+
+	if done, err := c.convertSpecial(dst, src); done {
+		return err
+	}
+
+	switch dst.Kind() { //nolint:exhaustive // Scalars are converted by default.
+	case reflect.Slice, reflect.Array:
+		return c.convertSlice(dst, src)
+	case reflect.Struct, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan:
+		if src.Kind() != dst.Kind() || !src.Type().ConvertibleTo(dst.Type()) {
+			return convertMismatch(dst, src)
+		}
+
+		dst.Set(src.Convert(dst.Type()))
+
+		return nil
+	default:
+		return convertScalar(dst, src)
+	}
+}
+
+// convertSpecial handles time, durations and text (un)marshalers.
+func (c converter) convertSpecial(dst, src reflect.Value) (bool, error) {
+	switch {
+	case dst.Type() == timeType && src.Kind() == reflect.String:
+		t, err := time.Parse(c.opts.TimeLayout, src.String())
+		if err == nil {
+			dst.Set(reflect.ValueOf(t))
+		}
+
+		return true, err
+	case src.Type() == timeType && dst.Kind() == reflect.String:
+		t, _ := src.Interface().(time.Time) //nolint:errcheck // Type is checked.
+		dst.SetString(t.Format(c.opts.TimeLayout))
+
+		return true, nil
+	case dst.Type() == durationType && src.Kind() == reflect.String:
+		d, err := time.ParseDuration(src.String())
+		if err == nil {
+			dst.SetInt(int64(d))
+		}
+
+		return true, err
+	case src.Type() == durationType && dst.Kind() == reflect.String:
+		dst.SetString(time.Duration(src.Int()).String())
+
+		return true, nil
+	case src.Kind() == reflect.String && dst.CanAddr() && dst.Addr().Type().Implements(textUnmarshaler):
+		tu, _ := dst.Addr().Interface().(encoding.TextUnmarshaler) //nolint:errcheck // Type is checked.
+
+		return true, tu.UnmarshalText([]byte(src.String()))
+	case dst.Kind() == reflect.String && src.Type().Implements(textMarshaler):
+		tm, _ := src.Interface().(encoding.TextMarshaler) //nolint:errcheck // Type is checked.
+
+		b, err := tm.MarshalText()
+		if err == nil {
+			dst.SetString(string(b))
+		}
+
+		return true, err
+	}
+
+	return false, nil
+}
+
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+func (c converter) convertSlice(dst, src reflect.Value) error {
+	if src.Kind() == reflect.String && isBytes(dst.Type()) {
+		dst.SetBytes([]byte(src.String()))
+
+		return nil
+	}
+
+	if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+		return convertMismatch(dst, src)
+	}
+
+	if dst.Kind() == reflect.Slice {
+		if src.Kind() == reflect.Slice && src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+
+			return nil
+		}
+
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+	} else if dst.Len() < src.Len() {
+		return fmt.Errorf("%w: %d items received for %s", ErrTypeMismatch, src.Len(), dst.Type().String())
+	}
+
+	for i := 0; i < src.Len(); i++ {
+		if err := c.convert(dst.Index(i), src.Index(i)); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// convertScalar sets scalar dst from src with weak typing: numbers are parsed from strings,
+// strings are formatted from numbers and booleans, numeric kinds are converted with overflow checks.
+func convertScalar(dst, src reflect.Value) error {
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+
+		return nil
+	}
+
+	var err error
+
+	switch dst.Kind() { //nolint:exhaustive // Non-scalar kinds are handled by caller.
+	case reflect.String:
+		if isBytes(src.Type()) {
+			dst.SetString(string(src.Bytes()))
+
+			return nil
+		}
+
+		err = convertToString(dst, src)
+	case reflect.Bool:
+		err = convertToBool(dst, src)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		err = convertToInt(dst, src)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		err = convertToUint(dst, src)
+	case reflect.Float32, reflect.Float64:
+		err = convertToFloat(dst, src)
+	default:
+		if src.Kind() != dst.Kind() || !src.Type().ConvertibleTo(dst.Type()) {
+			return convertMismatch(dst, src)
+		}
+
+		dst.Set(src.Convert(dst.Type()))
+	}
+
+	return err
+}
+
+func convertMismatch(dst, src reflect.Value) error {
+	return fmt.Errorf("%w: can not convert %s to %s", ErrTypeMismatch, src.Type().String(), dst.Type().String())
+}
+
+func convertOverflow(dst reflect.Value, value interface{}) error {
+	return fmt.Errorf("%w: value %v overflows %s", strconv.ErrRange, value, dst.Type().String())
+}
+
+func convertFraction(dst reflect.Value, value float64) error {
+	return fmt.Errorf("%w: value %v has fractional part and can not be converted to %s", ErrNotInteger, value, dst.Type().String())
+}
+
+func convertToString(dst, src reflect.Value) error {
+	switch src.Kind() { //nolint:exhaustive // Other kinds are not convertible.
+	case reflect.String:
+		dst.SetString(src.String())
+	case reflect.Bool:
+		dst.SetString(strconv.FormatBool(src.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		dst.SetString(strconv.FormatInt(src.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		dst.SetString(strconv.FormatUint(src.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		dst.SetString(strconv.FormatFloat(src.Float(), 'g', -1, src.Type().Bits()))
+	default:
+		return convertMismatch(dst, src)
+	}
+
+	return nil
+}
+
+func convertToBool(dst, src reflect.Value) error {
+	switch src.Kind() { //nolint:exhaustive // Other kinds are not convertible.
+	case reflect.Bool:
+		dst.SetBool(src.Bool())
+	case reflect.String:
+		v, err := strconv.ParseBool(src.String())
+		if err != nil {
+			return err
+		}
+
+		dst.SetBool(v)
+	default:
+		return convertMismatch(dst, src)
+	}
+
+	return nil
+}
+
+func convertToInt(dst, src reflect.Value) error {
+	var v int64
+
+	switch src.Kind() { //nolint:exhaustive // Other kinds are not convertible.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v = src.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if src.Uint() > math.MaxInt64 {
+			return convertOverflow(dst, src.Uint())
+		}
+
+		v = int64(src.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := src.Float()
+		if f != math.Trunc(f) {
+			return convertFraction(dst, f)
+		}
+
+		if f < math.MinInt64 || f >= math.MaxInt64 {
+			return convertOverflow(dst, f)
+		}
+
+		v = int64(f)
+	case reflect.String:
+		i, err := strconv.ParseInt(src.String(), 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v = i
+	default:
+		return convertMismatch(dst, src)
+	}
+
+	if dst.OverflowInt(v) {
+		return convertOverflow(dst, v)
+	}
+
+	dst.SetInt(v)
+
+	return nil
+}
+
+func convertToUint(dst, src reflect.Value) error {
+	var v uint64
+
+	switch src.Kind() { //nolint:exhaustive // Other kinds are not convertible.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if src.Int() < 0 {
+			return convertOverflow(dst, src.Int())
+		}
+
+		v = uint64(src.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v = src.Uint()
+	case reflect.Float32, reflect.Float64:
+		f := src.Float()
+		if f != math.Trunc(f) {
+			return convertFraction(dst, f)
+		}
+
+		if f < 0 || f >= math.MaxUint64 {
+			return convertOverflow(dst, f)
+		}
+
+		v = uint64(f)
+	case reflect.String:
+		u, err := strconv.ParseUint(src.String(), 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v = u
+	default:
+		return convertMismatch(dst, src)
+	}
+
+	if dst.OverflowUint(v) {
+		return convertOverflow(dst, v)
+	}
+
+	dst.SetUint(v)
+
+	return nil
+}
+
+func convertToFloat(dst, src reflect.Value) error {
+	var v float64
+
+	switch src.Kind() { //nolint:exhaustive // Other kinds are not convertible.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v = float64(src.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v = float64(src.Uint())
+	case reflect.Float32, reflect.Float64:
+		v = src.Float()
+	case reflect.String:
+		f, err := strconv.ParseFloat(src.String(), dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v = f
+	default:
+		return convertMismatch(dst, src)
+	}
+
+	if dst.OverflowFloat(v) {
+		return convertOverflow(dst, v)
+	}
+
+	dst.SetFloat(v)
+
+	return nil
+}
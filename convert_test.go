@@ -0,0 +1,131 @@
+package refl_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type convertStatus string
+
+func TestConvert_scalars(t *testing.T) {
+	var (
+		i8  int8
+		u   uint
+		i   int
+		f32 float32
+		s   string
+		b   bool
+		st  convertStatus
+	)
+
+	require.NoError(t, refl.Convert("127", &i8))
+	assert.Equal(t, int8(127), i8)
+
+	err := refl.Convert(128, &i8)
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+	assert.EqualError(t, err, "value out of range: value 128 overflows int8")
+
+	assert.EqualError(t, refl.Convert(-1, &u), "value out of range: value -1 overflows uint")
+
+	err = refl.Convert(1.5, &i)
+	assert.True(t, errors.Is(err, refl.ErrNotInteger))
+	assert.False(t, errors.Is(err, strconv.ErrRange))
+	assert.EqualError(t, err, "not an integer: value 1.5 has fractional part and can not be converted to int")
+	assert.EqualError(t, refl.Convert(2.5, &u), "not an integer: value 2.5 has fractional part and can not be converted to uint")
+	assert.EqualError(t, refl.Convert(-2.0, &u), "value out of range: value -2 overflows uint")
+	assert.EqualError(t, refl.Convert("abc", &i), `strconv.ParseInt: parsing "abc": invalid syntax`)
+
+	require.NoError(t, refl.Convert(json.Number("12"), &u))
+	assert.Equal(t, uint(12), u)
+
+	require.NoError(t, refl.Convert(3.0, &i))
+	assert.Equal(t, 3, i)
+
+	require.NoError(t, refl.Convert("1.25", &f32))
+	assert.Equal(t, float32(1.25), f32)
+
+	require.NoError(t, refl.Convert(1.25, &s))
+	assert.Equal(t, "1.25", s)
+
+	require.NoError(t, refl.Convert("t", &b))
+	assert.True(t, b)
+
+	require.NoError(t, refl.Convert(true, &s))
+	assert.Equal(t, "true", s)
+
+	require.NoError(t, refl.Convert("active", &st))
+	assert.Equal(t, convertStatus("active"), st)
+
+	require.NoError(t, refl.Convert(st, &s))
+	assert.Equal(t, "active", s)
+
+	assert.EqualError(t, refl.Convert(true, &i), "type mismatch: can not convert bool to int")
+	assert.True(t, errors.Is(refl.Convert(1, i), refl.ErrNeedPointer))
+}
+
+func TestConvert_special(t *testing.T) {
+	var (
+		d   time.Duration
+		tm  time.Time
+		s   string
+		ip  net.IP
+		pi  *int
+		arr [2]int
+		sl  []int64
+		bs  []byte
+	)
+
+	require.NoError(t, refl.Convert("1m30s", &d))
+	assert.Equal(t, 90*time.Second, d)
+
+	require.NoError(t, refl.Convert(d, &s))
+	assert.Equal(t, "1m30s", s)
+
+	require.NoError(t, refl.Convert(int64(time.Second), &d))
+	assert.Equal(t, time.Second, d)
+
+	require.NoError(t, refl.Convert("2021-02-03", &tm, func(o *refl.ConvertOptions) {
+		o.TimeLayout = "2006-01-02"
+	}))
+	assert.Equal(t, time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC), tm)
+
+	require.NoError(t, refl.Convert(tm, &s))
+	assert.Equal(t, "2021-02-03T00:00:00Z", s)
+
+	require.NoError(t, refl.Convert("127.0.0.1", &ip))
+	assert.Equal(t, "127.0.0.1", ip.String())
+
+	require.NoError(t, refl.Convert(ip, &s))
+	assert.Equal(t, "127.0.0.1", s)
+
+	require.NoError(t, refl.Convert("12", &pi))
+	assert.Equal(t, 12, *pi)
+
+	require.NoError(t, refl.Convert((*string)(nil), &pi))
+	assert.Nil(t, pi)
+
+	require.NoError(t, refl.Convert([]string{"1", "2"}, &arr))
+	assert.Equal(t, [2]int{1, 2}, arr)
+
+	require.NoError(t, refl.Convert(arr, &sl))
+	assert.Equal(t, []int64{1, 2}, sl)
+
+	require.NoError(t, refl.Convert("abc", &bs))
+	assert.Equal(t, []byte("abc"), bs)
+
+	require.NoError(t, refl.Convert(bs, &s))
+	assert.Equal(t, "abc", s)
+
+	assert.EqualError(t, refl.Convert([]string{"1", "a"}, &sl),
+		`item 1: strconv.ParseInt: parsing "a": invalid syntax`)
+	assert.EqualError(t, refl.Convert([]int{1, 2, 3}, &arr), "type mismatch: 3 items received for [2]int")
+	assert.EqualError(t, refl.Convert("1", &sl), "type mismatch: can not convert string to []int64")
+}
@@ -0,0 +1,199 @@
+package refl
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TypeInfo is a JSON-serializable description of a type.
+type TypeInfo struct {
+	// GoType is a type name with import path, see GoType.
+	GoType TypeString `json:"goType"`
+
+	// Kind is a name of reflect.Kind, for example "struct".
+	Kind string `json:"kind"`
+
+	// Ref is set instead of type details for a recursive type that is already described by a parent node,
+	// it is equal to GoType of that parent.
+	Ref TypeString `json:"ref,omitempty"`
+
+	// Len is a length of array.
+	Len int `json:"len,omitempty"`
+
+	// Fields describe struct fields.
+	Fields []FieldInfo `json:"fields,omitempty"`
+
+	// Elem describes element of slice, array or channel, or target of pointer.
+	Elem *TypeInfo `json:"elem,omitempty"`
+
+	// Key describes key of map.
+	Key *TypeInfo `json:"key,omitempty"`
+
+	// Value describes value of map.
+	Value *TypeInfo `json:"value,omitempty"`
+
+	// In describes function parameters.
+	In []*TypeInfo `json:"in,omitempty"`
+
+	// Out describes function results.
+	Out []*TypeInfo `json:"out,omitempty"`
+}
+
+// FieldInfo is a JSON-serializable description of a struct field.
+type FieldInfo struct {
+	Name     string    `json:"name"`
+	Embedded bool      `json:"embedded,omitempty"`
+	Exported bool      `json:"exported"`
+	Tags     []TagInfo `json:"tags,omitempty"`
+	Type     *TypeInfo `json:"type"`
+}
+
+// TagInfo is a parsed struct tag.
+type TagInfo struct {
+	// Key is a tag key, for example "json".
+	Key string `json:"key"`
+
+	// Value is a raw tag value, for example "name,omitempty".
+	Value string `json:"value"`
+
+	// Name is a value before first comma, for example "name".
+	Name string `json:"name,omitempty"`
+
+	// Options are comma-separated values after the name, for example ["omitempty"].
+	Options []string `json:"options,omitempty"`
+}
+
+// Describe returns a JSON-serializable description tree of a type.
+//
+// Recursive types are described once, nested occurrences have Ref to the parent type.
+func Describe(t reflect.Type) *TypeInfo {
+	if t == nil {
+		return nil
+	}
+
+	d := describer{walking: map[reflect.Type]bool{}}
+
+	return d.describe(t)
+}
+
+type describer struct {
+	walking map[reflect.Type]bool
+}
+
+func (d describer) describe(t reflect.Type) *TypeInfo {
+	ti := &TypeInfo{
+		GoType: GoType(t),
+		Kind:   t.Kind().String(),
+	}
+
+	if d.walking[t] {
+		ti.Ref = ti.GoType
+
+		return ti
+	}
+
+	d.walking[t] = true
+	defer delete(d.walking, t)
+
+	switch t.Kind() { //nolint:exhaustive // Other kinds have no details.
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+
+			ti.Fields = append(ti.Fields, FieldInfo{
+				Name:     sf.Name,
+				Embedded: sf.Anonymous,
+				Exported: sf.PkgPath == "",
+				Tags:     ParseTags(sf.Tag),
+				Type:     d.describe(sf.Type),
+			})
+		}
+	case reflect.Array:
+		ti.Len = t.Len()
+		ti.Elem = d.describe(t.Elem())
+	case reflect.Slice, reflect.Chan, reflect.Ptr:
+		ti.Elem = d.describe(t.Elem())
+	case reflect.Map:
+		ti.Key = d.describe(t.Key())
+		ti.Value = d.describe(t.Elem())
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			ti.In = append(ti.In, d.describe(t.In(i)))
+		}
+
+		for i := 0; i < t.NumOut(); i++ {
+			ti.Out = append(ti.Out, d.describe(t.Out(i)))
+		}
+	}
+
+	return ti
+}
+
+// ParseTags returns all key-value pairs of a struct tag in order of declaration.
+//
+// Parsing follows conventions of reflect.StructTag, malformed remainder of the tag is ignored.
+func ParseTags(tag reflect.StructTag) []TagInfo {
+	var res []TagInfo
+
+	s := string(tag)
+
+	for s != "" {
+		// Skip leading space.
+		i := 0
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+
+		s = s[i:]
+		if s == "" {
+			break
+		}
+
+		// Scan to colon, a space, a quote or a control character is a syntax error.
+		i = 0
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+
+		if i == 0 || i+1 >= len(s) || s[i] != ':' || s[i+1] != '"' {
+			break
+		}
+
+		key := s[:i]
+		s = s[i+1:]
+
+		// Scan quoted string to find value.
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+
+			i++
+		}
+
+		if i >= len(s) {
+			break
+		}
+
+		qvalue := s[:i+1]
+		s = s[i+1:]
+
+		value, err := strconv.Unquote(qvalue)
+		if err != nil {
+			break
+		}
+
+		parts := strings.Split(value, ",")
+		ti := TagInfo{Key: key, Value: value, Name: parts[0]}
+
+		if len(parts) > 1 {
+			ti.Options = parts[1:]
+		}
+
+		res = append(res, ti)
+	}
+
+	return res
+}
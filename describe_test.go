@@ -0,0 +1,171 @@
+package refl_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type describeBase struct {
+	ID int `json:"id" db:"id,pk"`
+}
+
+type describeNode struct {
+	describeBase
+	Parent   *describeNode         `json:"parent,omitempty"`
+	Labels   map[string][2]float64 `json:"labels"`
+	Callback func(int) error       `json:"-"`
+	hidden   bool
+}
+
+func TestDescribe(t *testing.T) {
+	ti := refl.Describe(reflect.TypeOf(describeNode{}))
+
+	j, err := json.MarshalIndent(ti, "", " ")
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+ "goType": "github.com/swaggest/refl_test.describeNode",
+ "kind": "struct",
+ "fields": [
+  {
+   "name": "describeBase",
+   "embedded": true,
+   "exported": false,
+   "type": {
+    "goType": "github.com/swaggest/refl_test.describeBase",
+    "kind": "struct",
+    "fields": [
+     {
+      "name": "ID",
+      "exported": true,
+      "tags": [
+       {
+        "key": "json",
+        "value": "id",
+        "name": "id"
+       },
+       {
+        "key": "db",
+        "value": "id,pk",
+        "name": "id",
+        "options": [
+         "pk"
+        ]
+       }
+      ],
+      "type": {
+       "goType": "int",
+       "kind": "int"
+      }
+     }
+    ]
+   }
+  },
+  {
+   "name": "Parent",
+   "exported": true,
+   "tags": [
+    {
+     "key": "json",
+     "value": "parent,omitempty",
+     "name": "parent",
+     "options": [
+      "omitempty"
+     ]
+    }
+   ],
+   "type": {
+    "goType": "*github.com/swaggest/refl_test.describeNode",
+    "kind": "ptr",
+    "elem": {
+     "goType": "github.com/swaggest/refl_test.describeNode",
+     "kind": "struct",
+     "ref": "github.com/swaggest/refl_test.describeNode"
+    }
+   }
+  },
+  {
+   "name": "Labels",
+   "exported": true,
+   "tags": [
+    {
+     "key": "json",
+     "value": "labels",
+     "name": "labels"
+    }
+   ],
+   "type": {
+    "goType": "map[string][2]float64",
+    "kind": "map",
+    "key": {
+     "goType": "string",
+     "kind": "string"
+    },
+    "value": {
+     "goType": "[2]float64",
+     "kind": "array",
+     "len": 2,
+     "elem": {
+      "goType": "float64",
+      "kind": "float64"
+     }
+    }
+   }
+  },
+  {
+   "name": "Callback",
+   "exported": true,
+   "tags": [
+    {
+     "key": "json",
+     "value": "-",
+     "name": "-"
+    }
+   ],
+   "type": {
+    "goType": "func(int) error",
+    "kind": "func",
+    "in": [
+     {
+      "goType": "int",
+      "kind": "int"
+     }
+    ],
+    "out": [
+     {
+      "goType": "error",
+      "kind": "interface"
+     }
+    ]
+   }
+  },
+  {
+   "name": "hidden",
+   "exported": false,
+   "type": {
+    "goType": "bool",
+    "kind": "bool"
+   }
+  }
+ ]
+}`, string(j))
+
+	var decoded refl.TypeInfo
+
+	require.NoError(t, json.Unmarshal(j, &decoded))
+	assert.Equal(t, *ti, decoded)
+
+	assert.Nil(t, refl.Describe(nil))
+}
+
+func TestParseTags(t *testing.T) {
+	assert.Equal(t, []refl.TagInfo{
+		{Key: "json", Value: "a,omitempty", Name: "a", Options: []string{"omitempty"}},
+		{Key: "description", Value: `Quoted "value", with comma.`, Name: `Quoted "value"`, Options: []string{" with comma."}},
+	}, refl.ParseTags(`json:"a,omitempty"  description:"Quoted \"value\", with comma." broken`))
+}
@@ -0,0 +1,312 @@
+package refl
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DiffKind describes kind of mismatch.
+type DiffKind string
+
+// Diff kinds.
+const (
+	// DiffValue means values are different.
+	DiffValue = DiffKind("value")
+
+	// DiffType means dynamic types of values are different.
+	DiffType = DiffKind("type")
+
+	// DiffAdded means item is only present in the right value.
+	DiffAdded = DiffKind("added")
+
+	// DiffRemoved means item is only present in the left value.
+	DiffRemoved = DiffKind("removed")
+)
+
+// Difference describes a mismatch found by Diff.
+type Difference struct {
+	Path  FieldPath
+	Kind  DiffKind
+	Left  interface{}
+	Right interface{}
+}
+
+// String implements fmt.Stringer.
+func (d Difference) String() string {
+	p := d.Path
+	if p == "" {
+		p = "<root>"
+	}
+
+	switch d.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("%s: added %v", p, d.Right)
+	case DiffRemoved:
+		return fmt.Sprintf("%s: removed %v", p, d.Left)
+	case DiffType:
+		return fmt.Sprintf("%s: type %T != %T", p, d.Left, d.Right)
+	default:
+		return fmt.Sprintf("%s: %v != %v", p, d.Left, d.Right)
+	}
+}
+
+// DiffOptions controls advanced behavior of Diff.
+type DiffOptions struct {
+	// TagName is a name of field tag that controls comparison, default "diff".
+	// Tag value "-" skips the field.
+	TagName string
+
+	// IgnorePaths lists paths that are not compared.
+	IgnorePaths []FieldPath
+
+	// IgnoreTypes lists types that are not compared.
+	IgnoreTypes []reflect.Type
+
+	// FloatTolerance is a maximum absolute difference of equal floats.
+	FloatTolerance float64
+
+	// NilEqualsEmpty makes nil slices and maps equal to empty ones.
+	NilEqualsEmpty bool
+}
+
+// Diff compares values deeply and returns a list of differences.
+//
+// Unexported fields are not compared. Structures that have `Equal(T) bool` method
+// (for example time.Time) are compared with that method.
+func Diff(a, b interface{}, options ...func(o *DiffOptions)) []Difference {
+	d := differ{
+		visited: map[diffVisit]bool{},
+		ignore:  map[FieldPath]bool{},
+		types:   map[reflect.Type]bool{},
+	}
+
+	for _, option := range options {
+		option(&d.opts)
+	}
+
+	if d.opts.TagName == "" {
+		d.opts.TagName = "diff"
+	}
+
+	for _, p := range d.opts.IgnorePaths {
+		d.ignore[p] = true
+	}
+
+	for _, t := range d.opts.IgnoreTypes {
+		d.types[t] = true
+	}
+
+	d.diff(reflect.ValueOf(a), reflect.ValueOf(b), "")
+
+	return d.res
+}
+
+type diffVisit struct {
+	a, b uintptr
+	t    reflect.Type
+}
+
+type differ struct {
+	opts    DiffOptions
+	ignore  map[FieldPath]bool
+	types   map[reflect.Type]bool
+	visited map[diffVisit]bool
+	res     []Difference
+}
+
+func (d *differ) add(path FieldPath, kind DiffKind, a, b reflect.Value) {
+	diff := Difference{Path: path, Kind: kind}
+
+	if a.IsValid() {
+		diff.Left = a.Interface()
+	}
+
+	if b.IsValid() {
+		diff.Right = b.Interface()
+	}
+
+	d.res = append(d.res, diff)
+}
+
+func (d *differ) diff(a, b reflect.Value, path FieldPath) {
+	if d.ignore[path] {
+		return
+	}
+
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			d.add(path, DiffValue, a, b)
+		}
+
+		return
+	}
+
+	if a.Type() != b.Type() {
+		d.add(path, DiffType, a, b)
+
+		return
+	}
+
+	if d.types[a.Type()] {
+		return
+	}
+
+	if eq, ok := equalMethod(a, b); ok {
+		if !eq {
+			d.add(path, DiffValue, a, b)
+		}
+
+		return
+	}
+
+	switch a.Kind() { //nolint:exhaustive // Other kinds are compared with ==.
+	case reflect.Ptr, reflect.Interface:
+		d.diffPtr(a, b, path)
+	case reflect.Struct:
+		d.diffStruct(a, b, path)
+	case reflect.Slice, reflect.Array:
+		d.diffSlice(a, b, path)
+	case reflect.Map:
+		d.diffMap(a, b, path)
+	case reflect.Float32, reflect.Float64:
+		if math.Abs(a.Float()-b.Float()) > d.opts.FloatTolerance {
+			d.add(path, DiffValue, a, b)
+		}
+	case reflect.Func:
+		if !a.IsNil() || !b.IsNil() {
+			d.add(path, DiffValue, a, b)
+		}
+	default:
+		if a.Interface() != b.Interface() {
+			d.add(path, DiffValue, a, b)
+		}
+	}
+}
+
+// equalMethod compares values with `Equal(T) bool` method if it is available.
+func equalMethod(a, b reflect.Value) (equal bool, ok bool) {
+	if a.Kind() != reflect.Struct {
+		return false, false
+	}
+
+	m := a.MethodByName("Equal")
+	if !m.IsValid() {
+		return false, false
+	}
+
+	mt := m.Type()
+	if mt.NumIn() != 1 || mt.In(0) != a.Type() || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.Bool {
+		return false, false
+	}
+
+	return m.Call([]reflect.Value{b})[0].Bool(), true
+}
+
+func (d *differ) diffPtr(a, b reflect.Value, path FieldPath) {
+	if a.IsNil() || b.IsNil() {
+		if a.IsNil() != b.IsNil() {
+			d.add(path, DiffValue, a, b)
+		}
+
+		return
+	}
+
+	if a.Kind() == reflect.Ptr {
+		if a.Pointer() == b.Pointer() {
+			return
+		}
+
+		k := diffVisit{a: a.Pointer(), b: b.Pointer(), t: a.Type()}
+		if d.visited[k] {
+			return
+		}
+
+		d.visited[k] = true
+	}
+
+	d.diff(a.Elem(), b.Elem(), path)
+}
+
+func (d *differ) diffStruct(a, b reflect.Value, path FieldPath) {
+	t := a.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		if strings.Split(sf.Tag.Get(d.opts.TagName), ",")[0] == "-" {
+			continue
+		}
+
+		d.diff(a.Field(i), b.Field(i), path.Field(sf.Name))
+	}
+}
+
+func (d *differ) nilMismatch(a, b reflect.Value, path FieldPath) bool {
+	if a.IsNil() == b.IsNil() {
+		return false
+	}
+
+	if d.opts.NilEqualsEmpty && a.Len() == 0 && b.Len() == 0 {
+		return false
+	}
+
+	d.add(path, DiffValue, a, b)
+
+	return true
+}
+
+func (d *differ) diffSlice(a, b reflect.Value, path FieldPath) {
+	if a.Kind() == reflect.Slice && d.nilMismatch(a, b, path) {
+		return
+	}
+
+	for i := 0; i < a.Len() || i < b.Len(); i++ {
+		switch {
+		case i >= b.Len():
+			d.add(path.Index(i), DiffRemoved, a.Index(i), reflect.Value{})
+		case i >= a.Len():
+			d.add(path.Index(i), DiffAdded, reflect.Value{}, b.Index(i))
+		default:
+			d.diff(a.Index(i), b.Index(i), path.Index(i))
+		}
+	}
+}
+
+func (d *differ) diffMap(a, b reflect.Value, path FieldPath) {
+	if d.nilMismatch(a, b, path) {
+		return
+	}
+
+	keys := append(a.MapKeys(), b.MapKeys()...)
+	sort.Slice(keys, func(i, j int) bool {
+		return mapKeyString(keys[i]) < mapKeyString(keys[j])
+	})
+
+	seen := make(map[interface{}]bool, len(keys))
+
+	for _, k := range keys {
+		if seen[k.Interface()] {
+			continue
+		}
+
+		seen[k.Interface()] = true
+
+		av := a.MapIndex(k)
+		bv := b.MapIndex(k)
+
+		switch {
+		case !bv.IsValid():
+			d.add(path.Key(k), DiffRemoved, av, bv)
+		case !av.IsValid():
+			d.add(path.Key(k), DiffAdded, av, bv)
+		default:
+			d.diff(av, bv, path.Key(k))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package refl_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/refl"
+	"github.com/swaggest/refl/internal/sample"
+)
+
+type diffEntity struct {
+	Name     string
+	Score    float64
+	Tags     []string
+	Labels   map[string]int
+	Sample   *sample.TestSampleStruct
+	Value    interface{}
+	Updated  time.Time
+	Secret   string `diff:"-"`
+	Next     *diffEntity
+	internal int
+}
+
+func TestDiff(t *testing.T) {
+	now := time.Now()
+
+	a := diffEntity{
+		Name:     "a",
+		Score:    1.0,
+		Tags:     []string{"x", "y"},
+		Labels:   map[string]int{"a": 1, "b": 2},
+		Sample:   &sample.TestSampleStruct{SubSlice: []sample.TestSubStruct{{SubInt: 1}}},
+		Value:    1,
+		Updated:  now,
+		Secret:   "a",
+		internal: 1,
+	}
+	a.Next = &a
+
+	b := diffEntity{
+		Name:     "b",
+		Score:    1.0000001,
+		Tags:     []string{"x"},
+		Labels:   map[string]int{"b": 3, "c": 4},
+		Sample:   &sample.TestSampleStruct{SubSlice: []sample.TestSubStruct{{SubInt: 2}}},
+		Value:    "1",
+		Updated:  now.Add(time.Second),
+		Secret:   "b",
+		internal: 2,
+	}
+	b.Next = &b
+
+	var res []string
+
+	for _, d := range refl.Diff(a, b) {
+		res = append(res, d.String())
+	}
+
+	assert.Equal(t, []string{
+		"Name: a != b",
+		"Score: 1 != 1.0000001",
+		"Tags[1]: removed y",
+		"Labels[a]: removed 1",
+		"Labels[b]: 2 != 3",
+		"Labels[c]: added 4",
+		"Sample.SubSlice[0].SubInt: 1 != 2",
+		"Value: type int != string",
+		fmt.Sprintf("Updated: %v != %v", now, now.Add(time.Second)),
+		"Next.Name: a != b",
+		"Next.Score: 1 != 1.0000001",
+		"Next.Tags[1]: removed y",
+		"Next.Labels[a]: removed 1",
+		"Next.Labels[b]: 2 != 3",
+		"Next.Labels[c]: added 4",
+		// Next.Sample is the same pair of pointers as Sample, it is not compared again.
+		"Next.Value: type int != string",
+		fmt.Sprintf("Next.Updated: %v != %v", now, now.Add(time.Second)),
+	}, res)
+
+	d := refl.Diff(a, b, func(o *refl.DiffOptions) {
+		o.FloatTolerance = 0.001
+		o.IgnorePaths = []refl.FieldPath{"Name", "Tags", "Labels", "Next"}
+		o.IgnoreTypes = []reflect.Type{reflect.TypeOf(time.Time{}), reflect.TypeOf(sample.TestSubStruct{})}
+	})
+
+	assert.Equal(t, []refl.Difference{{Path: "Value", Kind: refl.DiffType, Left: 1, Right: "1"}}, d)
+}
+
+func TestDiff_nilEqualsEmpty(t *testing.T) {
+	a := diffEntity{Tags: []string{}, Labels: map[string]int{}}
+	b := diffEntity{}
+
+	assert.Len(t, refl.Diff(a, b), 2)
+	assert.Empty(t, refl.Diff(a, b, func(o *refl.DiffOptions) {
+		o.NilEqualsEmpty = true
+	}))
+	assert.Empty(t, refl.Diff(nil, nil))
+	assert.Equal(t, []refl.Difference{{Kind: refl.DiffValue, Right: 1}}, refl.Diff(nil, 1))
+	assert.Equal(t, "<root>: <nil> != 1", refl.Diff(nil, 1)[0].String())
+}
@@ -0,0 +1,103 @@
+package refl
+
+import (
+	"reflect"
+)
+
+// FieldPredicate checks if struct field matches a condition.
+type FieldPredicate func(sf reflect.StructField) bool
+
+// FieldImplements matches fields whose type (or a pointer to it) implements an interface.
+//
+//	refl.FieldImplements(reflect.TypeOf((*json.Marshaler)(nil)).Elem())
+func FieldImplements(iface reflect.Type) FieldPredicate {
+	if iface.Kind() != reflect.Interface {
+		panic("interface type expected, " + iface.String() + " received")
+	}
+
+	return func(sf reflect.StructField) bool {
+		if sf.Type.Implements(iface) {
+			return true
+		}
+
+		return sf.Type.Kind() != reflect.Ptr && reflect.PtrTo(sf.Type).Implements(iface)
+	}
+}
+
+// FieldKind matches fields of any of the kinds, pointers are dereferenced.
+func FieldKind(kinds ...reflect.Kind) FieldPredicate {
+	return func(sf reflect.StructField) bool {
+		k := DeepIndirect(sf.Type).Kind()
+
+		for _, kind := range kinds {
+			if k == kind {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// FieldHasTag matches fields that have a tag with the name.
+func FieldHasTag(tagName string) FieldPredicate {
+	return func(sf reflect.StructField) bool {
+		_, ok := sf.Tag.Lookup(tagName)
+
+		return ok
+	}
+}
+
+// EmbeddedField describes anonymous field found with FindEmbedded.
+type EmbeddedField struct {
+	// Field is the matched anonymous field.
+	Field reflect.StructField
+
+	// Path is a chain of anonymous fields from the root structure to the matched field, inclusive.
+	Path []reflect.StructField
+}
+
+// FindEmbedded returns anonymous fields of a structure (or a pointer to it) that match predicate.
+//
+// Embedded structures and pointers to structures are searched recursively, embedded interfaces
+// are checked with predicate, but not traversed. Results are ordered depth-first, a matched field
+// precedes matches found inside of it.
+//
+// Only types are inspected, no values are created.
+func FindEmbedded(t reflect.Type, predicate FieldPredicate) []EmbeddedField {
+	if t == nil {
+		return nil
+	}
+
+	var res []EmbeddedField
+
+	findEmbedded(DeepIndirect(t), predicate, nil, map[reflect.Type]bool{}, &res)
+
+	return res
+}
+
+func findEmbedded(t reflect.Type, predicate FieldPredicate, path []reflect.StructField, visiting map[reflect.Type]bool, res *[]EmbeddedField) {
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.Anonymous {
+			continue
+		}
+
+		fieldPath := make([]reflect.StructField, len(path)+1)
+		copy(fieldPath, path)
+		fieldPath[len(path)] = f
+
+		if predicate(f) {
+			*res = append(*res, EmbeddedField{Field: f, Path: fieldPath})
+		}
+
+		findEmbedded(DeepIndirect(f.Type), predicate, fieldPath, visiting, res)
+	}
+}
@@ -0,0 +1,83 @@
+package refl_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type (
+	embeddedMarshaler struct {
+		json.RawMessage
+	}
+
+	embeddedRecursive struct {
+		*embeddedRecursive
+		Map `tagged:"yes"`
+	}
+
+	embeddedRoot struct {
+		Name string
+		*embeddedMarshaler
+		fmt.Stringer
+		embeddedRecursive
+	}
+)
+
+func TestFindEmbedded(t *testing.T) {
+	found := refl.FindEmbedded(reflect.TypeOf(new(embeddedRoot)), refl.FieldKind(reflect.Slice, reflect.Map))
+	require.Len(t, found, 2)
+
+	assert.Equal(t, "RawMessage", found[0].Field.Name)
+	assert.Equal(t, []string{"embeddedMarshaler", "RawMessage"}, fieldNames(found[0].Path))
+	assert.Equal(t, "Map", found[1].Field.Name)
+	assert.Equal(t, []string{"embeddedRecursive", "Map"}, fieldNames(found[1].Path))
+
+	found = refl.FindEmbedded(reflect.TypeOf(embeddedRoot{}),
+		refl.FieldImplements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()))
+	require.Len(t, found, 2)
+	assert.Equal(t, []string{"embeddedMarshaler"}, fieldNames(found[0].Path))
+	assert.Equal(t, []string{"embeddedMarshaler", "RawMessage"}, fieldNames(found[1].Path))
+
+	found = refl.FindEmbedded(reflect.TypeOf(embeddedRoot{}), refl.FieldKind(reflect.Interface))
+	require.Len(t, found, 1)
+	assert.Equal(t, "Stringer", found[0].Field.Name)
+
+	found = refl.FindEmbedded(reflect.TypeOf(embeddedRoot{}), refl.FieldHasTag("tagged"))
+	require.Len(t, found, 1)
+	assert.Equal(t, []string{"embeddedRecursive", "Map"}, fieldNames(found[0].Path))
+
+	assert.Nil(t, refl.FindEmbedded(reflect.TypeOf(123), refl.FieldHasTag("tagged")))
+	assert.Nil(t, refl.FindEmbedded(nil, refl.FieldHasTag("tagged")))
+	assert.Panics(t, func() {
+		refl.FieldImplements(reflect.TypeOf(123))
+	})
+}
+
+func fieldNames(path []reflect.StructField) []string {
+	res := make([]string, 0, len(path))
+
+	for _, f := range path {
+		res = append(res, f.Name)
+	}
+
+	return res
+}
+
+func BenchmarkFindEmbedded(b *testing.B) {
+	t := reflect.TypeOf(embeddedRoot{})
+	p := refl.FieldKind(reflect.Slice, reflect.Map)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if len(refl.FindEmbedded(t, p)) != 2 {
+			b.Fail()
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package refl
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type enumer interface {
+	Enum() []interface{}
+}
+
+var (
+	enumerType         = reflect.TypeOf((*enumer)(nil)).Elem()
+	packageConstsCache sync.Map
+)
+
+// EnumValues returns allowed values of a named type.
+//
+// If type implements Enum() []interface{} (with value or pointer receiver), result of the method is returned.
+// Otherwise source of the package that defines the type is parsed and type-checked offline with go/parser
+// and go/types, typed constants of the type are returned in order of declaration.
+// Package directory is resolved relative to moduleDir that contains go.mod, as in ReadTypeDoc.
+// Pointer types are dereferenced, interface types are not supported.
+//
+//	type Status string
+//
+//	const (
+//		StatusActive  = Status("active")
+//		StatusBlocked = Status("blocked")
+//	)
+func EnumValues(t reflect.Type, moduleDir string) ([]interface{}, error) {
+	for t.Kind() == reflect.Ptr && t.Name() == "" {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Interface {
+		return nil, fmt.Errorf("%w: non-interface type expected, %s received", ErrTypeMismatch, t.String())
+	}
+
+	if t.Implements(enumerType) {
+		return reflect.Zero(t).Interface().(enumer).Enum(), nil //nolint:forcetypeassert // Implementation is checked.
+	}
+
+	if reflect.PtrTo(t).Implements(enumerType) {
+		return reflect.New(t).Interface().(enumer).Enum(), nil //nolint:forcetypeassert // Implementation is checked.
+	}
+
+	if t.Name() == "" || t.PkgPath() == "" {
+		return nil, fmt.Errorf("%w: named type expected, %s received", ErrTypeMismatch, t.String())
+	}
+
+	dir, err := packageDir(t.PkgPath(), moduleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	consts, err := packageConsts(dir, strings.HasSuffix(t.PkgPath(), "_test"))
+	if err != nil {
+		return nil, err
+	}
+
+	var res []interface{}
+
+	for _, c := range consts {
+		named, ok := c.Type().(*types.Named)
+		if !ok || named.Obj().Name() != t.Name() {
+			continue
+		}
+
+		v, err := constValue(c.Val(), t)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Name(), err)
+		}
+
+		res = append(res, v)
+	}
+
+	return res, nil
+}
+
+// packageConsts returns package-level constants declared in directory sorted by position.
+func packageConsts(dir string, testPkg bool) ([]*types.Const, error) {
+	key := dir + ":" + fmt.Sprint(testPkg)
+
+	if cached, ok := packageConstsCache.Load(key); ok {
+		return cached.([]*types.Const), nil //nolint:forcetypeassert // Cache only has []*types.Const.
+	}
+
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, dir, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+
+	for name, pkg := range pkgs {
+		if strings.HasSuffix(name, "_test") != testPkg {
+			continue
+		}
+
+		for _, f := range pkg.Files {
+			if !testPkg && strings.HasSuffix(fset.File(f.Pos()).Name(), "_test.go") {
+				continue
+			}
+
+			files = append(files, f)
+		}
+	}
+
+	conf := types.Config{
+		// Imports are not resolved to keep checking fast and offline, constants of
+		// the package do not depend on them in most cases.
+		Importer: noImporter{},
+		// Errors of unrelated declarations, for example unresolved imports, do not affect constants.
+		Error: func(err error) {},
+	}
+
+	pkg, _ := conf.Check(dir, fset, files, nil) //nolint:errcheck // Errors are ignored to get partial result.
+
+	var consts []*types.Const
+
+	if pkg != nil {
+		for _, name := range pkg.Scope().Names() {
+			if c, ok := pkg.Scope().Lookup(name).(*types.Const); ok {
+				consts = append(consts, c)
+			}
+		}
+	}
+
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+
+	packageConstsCache.Store(key, consts)
+
+	return consts, nil
+}
+
+type noImporter struct{}
+
+func (noImporter) Import(path string) (*types.Package, error) {
+	return nil, fmt.Errorf("%w: import of %s is disabled", ErrNotImplemented, path)
+}
+
+func constValue(c constant.Value, t reflect.Type) (interface{}, error) {
+	v := reflect.New(t).Elem()
+
+	switch t.Kind() { //nolint:exhaustive // Other kinds can not be constants.
+	case reflect.String:
+		v.SetString(constant.StringVal(c))
+	case reflect.Bool:
+		v.SetBool(constant.BoolVal(c))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := constant.Int64Val(constant.ToInt(c))
+		if !ok || v.OverflowInt(i) {
+			return nil, fmt.Errorf("%w: can not convert %s to %s", ErrTypeMismatch, c.String(), t.String())
+		}
+
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, ok := constant.Uint64Val(constant.ToInt(c))
+		if !ok || v.OverflowUint(u) {
+			return nil, fmt.Errorf("%w: can not convert %s to %s", ErrTypeMismatch, c.String(), t.String())
+		}
+
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, _ := constant.Float64Val(constant.ToFloat(c))
+		v.SetFloat(f)
+	default:
+		return nil, fmt.Errorf("%w: can not convert %s to %s", ErrTypeMismatch, c.String(), t.String())
+	}
+
+	return v.Interface(), nil
+}
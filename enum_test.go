@@ -0,0 +1,79 @@
+package refl_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type enumStatus string
+
+const (
+	enumStatusActive  = enumStatus("active")
+	enumStatusBlocked = enumStatus("blocked")
+	enumNotStatus     = "plain"
+)
+
+const enumStatusDeleted enumStatus = "deleted"
+
+type enumLevel uint8
+
+const (
+	enumLevelLow enumLevel = iota + 1
+	enumLevelMid
+	enumLevelHigh
+)
+
+type enumColor int
+
+func (enumColor) Enum() []interface{} {
+	return []interface{}{enumColor(1), enumColor(2)}
+}
+
+type enumShape string
+
+func (*enumShape) Enum() []interface{} {
+	return []interface{}{"circle", "square"}
+}
+
+func TestEnumValues(t *testing.T) {
+	_ = enumNotStatus
+
+	vals, err := refl.EnumValues(reflect.TypeOf(enumStatus("")), ".")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{enumStatusActive, enumStatusBlocked, enumStatusDeleted}, vals)
+
+	vals, err = refl.EnumValues(reflect.TypeOf(enumLevel(0)), ".")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{enumLevelLow, enumLevelMid, enumLevelHigh}, vals)
+
+	vals, err = refl.EnumValues(reflect.TypeOf(enumColor(0)), ".")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{enumColor(1), enumColor(2)}, vals)
+
+	vals, err = refl.EnumValues(reflect.TypeOf(enumShape("")), ".")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"circle", "square"}, vals)
+
+	vals, err = refl.EnumValues(reflect.TypeOf(refl.RedactMode("")), ".")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{refl.RedactMask, refl.RedactZero, refl.RedactHash}, vals)
+
+	vals, err = refl.EnumValues(reflect.TypeOf(new(enumColor)), ".")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{enumColor(1), enumColor(2)}, vals)
+
+	vals, err = refl.EnumValues(reflect.TypeOf(new(enumStatus)), ".")
+	require.NoError(t, err)
+	assert.Len(t, vals, 3)
+
+	_, err = refl.EnumValues(reflect.TypeOf((*interface{ Enum() []interface{} })(nil)).Elem(), ".")
+	assert.True(t, errors.Is(err, refl.ErrTypeMismatch))
+
+	_, err = refl.EnumValues(reflect.TypeOf(""), ".")
+	assert.True(t, errors.Is(err, refl.ErrTypeMismatch))
+}
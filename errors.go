@@ -0,0 +1,126 @@
+package refl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MultiError is a list of errors that keeps each of them available to errors.Is and errors.As.
+//
+// It is returned by JoinErrors and helpers that aggregate failures, individual errors can be
+// iterated with range.
+type MultiError []error
+
+// Error implements error, messages are joined with ", ".
+func (me MultiError) Error() string {
+	msgs := make([]string, 0, len(me))
+
+	for _, err := range me {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, ", ")
+}
+
+// Unwrap returns wrapped errors.
+func (me MultiError) Unwrap() []error {
+	return me
+}
+
+// Is checks if any of wrapped errors matches target.
+func (me MultiError) Is(target error) bool {
+	for _, err := range me {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first wrapped error that matches target.
+func (me MultiError) As(target interface{}) bool {
+	for _, err := range me {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FieldError describes a failure to read a field value from a tag or to decode it.
+//
+// It is returned by tag readers, like ReadIntTag, and by decoders, like FromMap or DecodeValues.
+type FieldError struct {
+	// Path is a location of the field with tag names, for example "sub_slice[1].sample_int".
+	// It is empty for errors of tag readers, PopulateFieldsFromTags sets it to a name of the
+	// populated field, for example "Min".
+	Path string
+
+	// Tag is a key of struct tag, for example "min" for tag readers or "json" for FromMap.
+	Tag string
+
+	// Value is a raw value that has failed.
+	Value interface{}
+
+	// Type is a target type.
+	Type reflect.Type
+
+	// Err is a cause of failure.
+	Err error
+
+	// tagValue marks errors of tag readers, their message keeps the same format when Path is set.
+	tagValue bool
+}
+
+// Error implements error.
+func (fe FieldError) Error() string {
+	if fe.Path != "" && !fe.tagValue {
+		return fe.Path + ": " + fe.Err.Error()
+	}
+
+	kind := ""
+
+	if fe.Type != nil {
+		switch fe.Type.Kind() { //nolint:exhaustive // Other kinds are named by type.
+		case reflect.Bool:
+			kind = "bool"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			kind = "int"
+		case reflect.Float32, reflect.Float64:
+			kind = "float"
+		default:
+			kind = fe.Type.String()
+		}
+	}
+
+	return fmt.Sprintf("failed to parse %s value %v in tag %s: %v", kind, fe.Value, fe.Tag, fe.Err)
+}
+
+// Unwrap returns cause of failure.
+func (fe FieldError) Unwrap() error {
+	return fe.Err
+}
+
+// tagErr returns FieldError of a tag reader.
+func tagErr(name, value string, t reflect.Type, err error) error {
+	return FieldError{Tag: name, Value: value, Type: t, Err: err, tagValue: true}
+}
+
+// fieldErr returns FieldError for a non-nil error, it returns nil otherwise.
+func fieldErr(path, tag string, value reflect.Value, t reflect.Type, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	fe := FieldError{Path: path, Tag: tag, Type: t, Err: err}
+
+	if value.IsValid() && value.CanInterface() {
+		fe.Value = value.Interface()
+	}
+
+	return fe
+}
@@ -0,0 +1,106 @@
+package refl_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+	"github.com/swaggest/refl/internal/sample"
+)
+
+func TestJoinErrors(t *testing.T) {
+	assert.NoError(t, refl.JoinErrors())
+	assert.NoError(t, refl.JoinErrors(nil, nil))
+
+	e1 := errors.New("e1")
+	e2 := errors.New("e2")
+	e3 := errors.New("e3")
+
+	err := refl.JoinErrors(e1, nil, refl.JoinErrors(e2, e3))
+	assert.EqualError(t, err, "e1, e2, e3")
+
+	var me refl.MultiError
+
+	require.True(t, errors.As(err, &me))
+	assert.Equal(t, refl.MultiError{e1, e2, e3}, me)
+	assert.Equal(t, []error{e1, e2, e3}, me.Unwrap())
+	assert.True(t, errors.Is(err, e3))
+	assert.False(t, errors.Is(err, refl.ErrTypeMismatch))
+}
+
+func TestMultiError_populateFieldsFromTags(t *testing.T) {
+	type value struct {
+		Property string `min:"abc" limit:"5" deprecated:"c"`
+	}
+
+	s := schema{}
+
+	err := refl.PopulateFieldsFromTags(&s, reflect.TypeOf(value{}).Field(0).Tag)
+	assert.EqualError(t, err,
+		"failed to parse float value abc in tag min: strconv.ParseFloat: parsing \"abc\": invalid syntax, "+
+			"failed to parse bool value c in tag deprecated: strconv.ParseBool: parsing \"c\": invalid syntax")
+
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+
+	var numErr *strconv.NumError
+
+	require.True(t, errors.As(err, &numErr))
+	assert.Equal(t, "ParseFloat", numErr.Func)
+
+	var me refl.MultiError
+
+	require.True(t, errors.As(err, &me))
+	require.Len(t, me, 2)
+
+	for _, e := range me {
+		assert.True(t, errors.Is(e, strconv.ErrSyntax))
+	}
+
+	var fe refl.FieldError
+
+	require.True(t, errors.As(me[0], &fe))
+	assert.Equal(t, "Min", fe.Path)
+	assert.Equal(t, "min", fe.Tag)
+	require.True(t, errors.As(me[1], &fe))
+	assert.Equal(t, "Deprecated", fe.Path)
+}
+
+func TestFieldError(t *testing.T) {
+	type value struct {
+		Property string `min:"abc"`
+	}
+
+	var f float64
+
+	err := refl.ReadFloatTag(reflect.TypeOf(value{}).Field(0).Tag, "min", &f)
+	assert.EqualError(t, err, `failed to parse float value abc in tag min: strconv.ParseFloat: parsing "abc": invalid syntax`)
+
+	var fe refl.FieldError
+
+	require.True(t, errors.As(err, &fe))
+	assert.Equal(t, "", fe.Path)
+	assert.Equal(t, "min", fe.Tag)
+	assert.Equal(t, "abc", fe.Value)
+	assert.Equal(t, reflect.TypeOf(0.0), fe.Type)
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+
+	var s sample.TestSampleStruct
+
+	err = refl.FromMap(map[string]interface{}{
+		"sub_slice": []interface{}{
+			map[string]interface{}{"sample_int": 1},
+			map[string]interface{}{"sample_int": "abc"},
+		},
+	}, &s, "json")
+	assert.EqualError(t, err, `sub_slice[1].sample_int: strconv.ParseInt: parsing "abc": invalid syntax`)
+
+	require.True(t, errors.As(err, &fe))
+	assert.Equal(t, "sub_slice[1].sample_int", fe.Path)
+	assert.Equal(t, "json", fe.Tag)
+	assert.Equal(t, "abc", fe.Value)
+	assert.Equal(t, reflect.TypeOf(0), fe.Type)
+}
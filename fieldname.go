@@ -0,0 +1,134 @@
+package refl
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+type fieldNamesKey struct {
+	t       reflect.Type
+	tagName string
+}
+
+type fieldOffset struct {
+	offset uintptr
+	t      reflect.Type
+}
+
+type fieldNames struct {
+	base  interface{}
+	size  uintptr
+	names map[fieldOffset]string
+}
+
+var fieldNamesCache sync.Map
+
+// FieldName returns tagged name of a structure field selected by a function, it panics on error.
+//
+//	name := refl.FieldName(func(e *MyEntity) interface{} { return &e.UpdatedAt }, "db")
+//
+// See FindFieldName.
+func FieldName[T any](selector func(t *T) interface{}, tagName string) string {
+	name, err := FindFieldName(selector, tagName)
+	if err != nil {
+		panic(err)
+	}
+
+	return name
+}
+
+// FindFieldName returns tagged name of a structure field selected by a function.
+//
+// Selector receives a pointer to a shared zero value of T and must return a pointer to a field
+// without modifying or retaining the value. Fields of nested structures are named with tagged paths
+// as in FindTaggedPath, fields behind pointers are not reachable, ErrMissingFieldValue is returned
+// if selector panics on a nil pointer.
+//
+// Field offsets are collected once per type and tag name, so the call costs about a map lookup.
+func FindFieldName[T any](selector func(t *T) interface{}, tagName string) (string, error) {
+	key := fieldNamesKey{t: reflect.TypeOf((*T)(nil)).Elem(), tagName: tagName}
+
+	var fn *fieldNames
+
+	if cached, ok := fieldNamesCache.Load(key); ok {
+		fn = cached.(*fieldNames) //nolint:forcetypeassert // Cache only has *fieldNames.
+	} else {
+		fn = newFieldNames(key.t, tagName, new(T))
+		fieldNamesCache.Store(key, fn)
+	}
+
+	base := fn.base.(*T) //nolint:forcetypeassert // Base is created for T.
+
+	p, ok := selectField(selector, base)
+	if !ok || p.Kind() != reflect.Ptr || p.IsNil() {
+		return "", ErrMissingFieldValue
+	}
+
+	start := uintptr(unsafe.Pointer(base)) //nolint:gosec // Address is only used to calculate offset.
+	if p.Pointer() < start || p.Pointer() >= start+fn.size {
+		return "", ErrMissingFieldValue
+	}
+
+	name, ok := fn.names[fieldOffset{offset: p.Pointer() - start, t: p.Type().Elem()}]
+	if !ok {
+		return "", ErrMissingFieldValue
+	}
+
+	return name, nil
+}
+
+// selectField calls selector, it returns false if selector panics, for example on a nil pointer
+// of shared zero value.
+func selectField[T any](selector func(t *T) interface{}, base *T) (p reflect.Value, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+		}
+	}()
+
+	return reflect.ValueOf(selector(base)), true
+}
+
+func newFieldNames(t reflect.Type, tagName string, base interface{}) *fieldNames {
+	fn := &fieldNames{
+		base:  base,
+		size:  t.Size(),
+		names: map[fieldOffset]string{},
+	}
+
+	if t.Kind() == reflect.Struct {
+		fn.collect(t, 0, "", tagName)
+	}
+
+	return fn
+}
+
+func (fn *fieldNames) collect(t reflect.Type, offset uintptr, name, tagName string) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag := strings.Split(sf.Tag.Get(tagName), ",")[0]
+		if tag == "-" || (!sf.Anonymous && tag == "") {
+			continue
+		}
+
+		fieldName := name
+		if !sf.Anonymous {
+			fieldName = tag
+			if name != "" {
+				fieldName = name + "." + tag
+			}
+
+			fo := fieldOffset{offset: offset + sf.Offset, t: sf.Type}
+			if _, ok := fn.names[fo]; !ok {
+				fn.names[fo] = fieldName
+			}
+		}
+
+		if sf.Type.Kind() == reflect.Struct {
+			fn.collect(sf.Type, offset+sf.Offset, fieldName, tagName)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package refl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+	"github.com/swaggest/refl/internal/sample"
+)
+
+type fieldNameBase struct {
+	ID int `db:"id"`
+}
+
+type fieldNameEntity struct {
+	fieldNameBase
+	Name      string    `db:"name"`
+	UpdatedAt time.Time `db:"updated_at"`
+	Skipped   string    `db:"-"`
+	Ptr       *sample.TestSubStruct
+	Sub       sample.TestSubStruct `db:"sub"`
+}
+
+func TestFieldName(t *testing.T) {
+	assert.Equal(t, "updated_at", refl.FieldName(func(e *fieldNameEntity) interface{} { return &e.UpdatedAt }, "db"))
+	assert.Equal(t, "id", refl.FieldName(func(e *fieldNameEntity) interface{} { return &e.ID }, "db"))
+	assert.Equal(t, "name", refl.FieldName(func(e *fieldNameEntity) interface{} { return &e.Name }, "db"))
+	assert.Equal(t, "sub", refl.FieldName(func(e *fieldNameEntity) interface{} { return &e.Sub }, "db"))
+
+	name, err := refl.FindFieldName(func(e *sample.TestSampleStruct) interface{} { return &e.Sub.SubInt }, "json")
+	require.NoError(t, err)
+	assert.Equal(t, "sub.sample_int", name)
+
+	_, err = refl.FindFieldName(func(e *fieldNameEntity) interface{} { return &e.Skipped }, "db")
+	assert.Equal(t, refl.ErrMissingFieldValue, err)
+
+	_, err = refl.FindFieldName(func(e *fieldNameEntity) interface{} { return e.ID }, "db")
+	assert.Equal(t, refl.ErrMissingFieldValue, err)
+
+	_, err = refl.FindFieldName(func(e *fieldNameEntity) interface{} { return &e.Ptr.SubInt }, "db")
+	assert.Equal(t, refl.ErrMissingFieldValue, err)
+
+	assert.Panics(t, func() {
+		refl.FieldName(func(e *fieldNameEntity) interface{} { return &e.Sub.SubInt }, "db")
+	})
+}
+
+func BenchmarkFieldName(b *testing.B) {
+	selector := func(e *fieldNameEntity) interface{} { return &e.UpdatedAt }
+
+	for i := 0; i < b.N; i++ {
+		if refl.FieldName(selector, "db") != "updated_at" {
+			b.Fail()
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package refl
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// FieldPath is a location of a nested value relative to the root value.
+//
+// Go field names are separated with dots, slice and array indexes and map keys are enclosed
+// in brackets, for example "Sub.Items[0].Labels[env]". Empty path denotes the root value.
+type FieldPath string
+
+// Field returns path of a struct field.
+func (p FieldPath) Field(name string) FieldPath {
+	if p == "" {
+		return FieldPath(name)
+	}
+
+	return p + "." + FieldPath(name)
+}
+
+// Index returns path of a slice or array item.
+func (p FieldPath) Index(i int) FieldPath {
+	return p + "[" + FieldPath(strconv.Itoa(i)) + "]"
+}
+
+// Key returns path of a map item.
+func (p FieldPath) Key(k reflect.Value) FieldPath {
+	return p + "[" + FieldPath(mapKeyString(k)) + "]"
+}
+
+// String implements fmt.Stringer.
+func (p FieldPath) String() string {
+	return string(p)
+}
@@ -0,0 +1,18 @@
+package refl_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/refl"
+)
+
+func TestFieldPath(t *testing.T) {
+	var p refl.FieldPath
+
+	p = p.Field("Sub").Field("Items").Index(0).Field("Labels").Key(reflect.ValueOf("env"))
+
+	assert.Equal(t, "Sub.Items[0].Labels[env]", p.String())
+	assert.Equal(t, refl.FieldPath("[1]"), refl.FieldPath("").Index(1))
+}
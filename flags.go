@@ -0,0 +1,190 @@
+package refl
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BindFlagsOptions controls advanced behavior of BindFlags.
+type BindFlagsOptions struct {
+	// Prefix is prepended to flag names with a dot, for example "app" turns "host" into "app.host".
+	Prefix string
+
+	ConvertOptions
+}
+
+// BindFlags registers a flag for each field of a structure pointer that has `flag` tag.
+//
+// Flag usage is taken from `description` tag and default value from `default` tag,
+// current non-zero field value is used as default if `default` tag is missing.
+// Nested structures are registered with dotted prefixes, for example "db.host", embedded structures
+// are flattened. Slices receive repeated flags, the first flag replaces default value,
+// default value of a slice is a comma-separated list.
+// Values are converted as in Convert, so durations, times and encoding.TextUnmarshaler are supported.
+//
+//	type Config struct {
+//		Listen  string        `flag:"listen" default:":8080" description:"Address to listen."`
+//		Timeout time.Duration `flag:"timeout" default:"5s"`
+//		DB      struct {
+//			Host string `flag:"host"`
+//		} `flag:"db"`
+//	}
+func BindFlags(fs *flag.FlagSet, structPtr interface{}, options ...func(o *BindFlagsOptions)) error {
+	v := reflect.ValueOf(structPtr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrNeedPointer
+	}
+
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("%w, %s received", ErrStructExpected, v.Type().String())
+	}
+
+	opts := BindFlagsOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	b := flagBinder{fs: fs, conv: newConverter(opts.ConvertOptions)}
+
+	return b.bind(v, opts.Prefix)
+}
+
+type flagBinder struct {
+	fs   *flag.FlagSet
+	conv converter
+}
+
+func (b flagBinder) bind(v reflect.Value, prefix string) error {
+	allocEmbedded(v)
+
+	var errs []error
+
+	WalkTaggedFields(v, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		if !fv.CanSet() {
+			return
+		}
+
+		name := tag
+		if prefix != "" {
+			name = prefix + "." + tag
+		}
+
+		t := fv.Type()
+
+		if !isFlatLeaf(t) && DeepIndirect(t).Kind() == reflect.Struct {
+			if t.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					fv.Set(reflect.New(t.Elem()))
+				}
+
+				fv = fv.Elem()
+			}
+
+			if err := b.bind(fv, name); err != nil {
+				errs = append(errs, err)
+			}
+
+			return
+		}
+
+		if !isFlatLeaf(t) && (t.Kind() != reflect.Slice || !isFlatLeaf(t.Elem())) {
+			return
+		}
+
+		ff := &fieldFlag{v: fv, conv: b.conv}
+
+		if def, ok := sf.Tag.Lookup("default"); ok {
+			defs := []string{def}
+			if !isFlatLeaf(t) {
+				defs = strings.Split(def, ",")
+			}
+
+			for _, d := range defs {
+				if err := ff.Set(d); err != nil {
+					errs = append(errs, fieldErr(name, "default", reflect.ValueOf(d), t, err))
+
+					return
+				}
+			}
+		}
+
+		ff.set = false
+
+		b.fs.Var(ff, name, sf.Tag.Get("description"))
+	}, "flag")
+
+	return JoinErrors(errs...)
+}
+
+// fieldFlag is a flag.Value adapter for a settable field value.
+type fieldFlag struct {
+	v    reflect.Value
+	conv converter
+	set  bool
+}
+
+// String implements flag.Value.
+func (f *fieldFlag) String() string {
+	if f == nil || !f.v.IsValid() {
+		return ""
+	}
+
+	v := f.v
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Slice && !isFlatLeaf(v.Type()) {
+		var items []string
+		if err := f.conv.convert(reflect.ValueOf(&items).Elem(), v); err != nil {
+			return ""
+		}
+
+		return fmt.Sprint(items)
+	}
+
+	var s string
+	if err := f.conv.convert(reflect.ValueOf(&s).Elem(), v); err != nil {
+		return ""
+	}
+
+	return s
+}
+
+// Set implements flag.Value.
+func (f *fieldFlag) Set(s string) error {
+	if f.v.Kind() == reflect.Slice && !isFlatLeaf(f.v.Type()) {
+		item := reflect.New(f.v.Type().Elem()).Elem()
+		if err := f.conv.convert(item, reflect.ValueOf(s)); err != nil {
+			return err
+		}
+
+		if !f.set {
+			f.v.Set(reflect.MakeSlice(f.v.Type(), 0, 1))
+			f.set = true
+		}
+
+		f.v.Set(reflect.Append(f.v, item))
+
+		return nil
+	}
+
+	return f.conv.convert(f.v, reflect.ValueOf(s))
+}
+
+// Get implements flag.Getter.
+func (f *fieldFlag) Get() interface{} {
+	return f.v.Interface()
+}
+
+// IsBoolFlag allows bool flags without values, e.g. "-verbose".
+func (f *fieldFlag) IsBoolFlag() bool {
+	return f.v.IsValid() && DeepIndirect(f.v.Type()).Kind() == reflect.Bool
+}
@@ -0,0 +1,84 @@
+package refl_test
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type flagsLog struct {
+	Verbose bool `flag:"verbose" description:"Enable verbose logging."`
+}
+
+type flagsConfig struct {
+	flagsLog
+	Listen  string        `flag:"listen" default:":8080" description:"Address to listen."`
+	Timeout time.Duration `flag:"timeout" default:"5s"`
+	Tags    []string      `flag:"tag" default:"a,b"`
+	Ports   []int         `flag:"port"`
+	Limit   *int          `flag:"limit"`
+	Ignored string
+	DB      struct {
+		Host string `flag:"host" default:"localhost"`
+		Port int    `flag:"port"`
+	} `flag:"db"`
+	Cache *struct {
+		TTL time.Duration `flag:"ttl"`
+	} `flag:"cache"`
+}
+
+func TestBindFlags(t *testing.T) {
+	var cfg flagsConfig
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	require.NoError(t, refl.BindFlags(fs, &cfg))
+
+	assert.Equal(t, ":8080", cfg.Listen)
+	assert.Equal(t, 5*time.Second, cfg.Timeout)
+	assert.Equal(t, []string{"a", "b"}, cfg.Tags)
+	assert.Equal(t, "localhost", cfg.DB.Host)
+	assert.Equal(t, ":8080", fs.Lookup("listen").DefValue)
+	assert.Equal(t, "Address to listen.", fs.Lookup("listen").Usage)
+	assert.Nil(t, fs.Lookup("Ignored"))
+
+	require.NoError(t, fs.Parse([]string{
+		"-verbose", "-timeout", "1m", "-tag", "c", "-port", "1", "-port", "2",
+		"-limit", "10", "-db.port", "5432", "-cache.ttl", "2s",
+	}))
+
+	assert.True(t, cfg.Verbose)
+	assert.Equal(t, time.Minute, cfg.Timeout)
+	assert.Equal(t, []string{"c"}, cfg.Tags)
+	assert.Equal(t, []int{1, 2}, cfg.Ports)
+	require.NotNil(t, cfg.Limit)
+	assert.Equal(t, 10, *cfg.Limit)
+	assert.Equal(t, 5432, cfg.DB.Port)
+	require.NotNil(t, cfg.Cache)
+	assert.Equal(t, 2*time.Second, cfg.Cache.TTL)
+
+	out := bytes.NewBuffer(nil)
+	fs.SetOutput(out)
+	fs.PrintDefaults()
+	assert.Contains(t, out.String(), "-db.host value")
+	assert.Contains(t, out.String(), "(default localhost)")
+
+	assert.Error(t, fs.Parse([]string{"-db.port", "abc"}))
+}
+
+func TestBindFlags_errors(t *testing.T) {
+	var cfg struct {
+		Port int `flag:"port" default:"abc"`
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	assert.EqualError(t, refl.BindFlags(fs, &cfg, func(o *refl.BindFlagsOptions) {
+		o.Prefix = "app"
+	}), `app.port: strconv.ParseInt: parsing "abc": invalid syntax`)
+	assert.Equal(t, refl.ErrNeedPointer, refl.BindFlags(fs, cfg))
+}
@@ -0,0 +1,369 @@
+package refl
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FlattenOptions controls advanced behavior of Flatten and Unflatten.
+type FlattenOptions struct {
+	// Separator is put between field names, default ".".
+	Separator string
+
+	// IndexPrefix is put before slice index or map key, default is Separator.
+	IndexPrefix string
+
+	// IndexSuffix is put after slice index or map key, default is empty.
+	//
+	// For example, IndexPrefix "[" and IndexSuffix "]" produce keys like "sub_slice[0].sample_int".
+	IndexSuffix string
+
+	ConvertOptions
+}
+
+type flattener struct {
+	tagName string
+	opts    FlattenOptions
+	conv    converter
+
+	// visiting holds pointers that are being descended to stop on cyclic values.
+	visiting map[uintptr]bool
+}
+
+func newFlattener(tagName string, options []func(o *FlattenOptions)) flattener {
+	f := flattener{tagName: tagName, visiting: map[uintptr]bool{}}
+
+	for _, option := range options {
+		option(&f.opts)
+	}
+
+	if f.opts.Separator == "" {
+		f.opts.Separator = "."
+	}
+
+	if f.opts.IndexPrefix == "" {
+		f.opts.IndexPrefix = f.opts.Separator
+	}
+
+	f.conv = newConverter(f.opts.ConvertOptions)
+
+	return f
+}
+
+func (f flattener) field(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + f.opts.Separator + name
+}
+
+func (f flattener) index(prefix, index string) string {
+	return prefix + f.opts.IndexPrefix + index + f.opts.IndexSuffix
+}
+
+// isFlatLeaf checks if type is converted to a single string value.
+func isFlatLeaf(t reflect.Type) bool {
+	t = DeepIndirect(t)
+
+	switch t.Kind() { //nolint:exhaustive // Other kinds are leaves.
+	case reflect.Struct:
+		return isMarshaler(t)
+	case reflect.Slice:
+		return isBytes(t) || isMarshaler(t)
+	case reflect.Array, reflect.Map, reflect.Interface:
+		return isMarshaler(t)
+	}
+
+	return true
+}
+
+// Flatten converts structure (or a pointer to it) into a flat map of keys and string values.
+//
+// Keys are built from tag names of nested fields, slice indexes and map keys, for example
+// "sub.sample_int" and "sub_slice.0.sample_int". Untagged fields and fields tagged with "-" are skipped,
+// nil pointers and cyclic references are skipped, embedded structures are flattened.
+// Values are converted to strings with Convert.
+//
+// Nested structures are traversed with WalkTaggedFields instead of WalkFieldsRecursively,
+// because the latter skips fields by visited type and panics on cyclic values.
+func Flatten(v interface{}, tagName string, options ...func(o *FlattenOptions)) map[string]string {
+	f := newFlattener(tagName, options)
+	res := map[string]string{}
+
+	f.flatten(reflect.ValueOf(v), "", res)
+
+	return res
+}
+
+func (f flattener) flatten(v reflect.Value, prefix string, res map[string]string) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+
+		if v.Kind() == reflect.Ptr {
+			if f.visiting[v.Pointer()] {
+				return
+			}
+
+			f.visiting[v.Pointer()] = true
+			defer delete(f.visiting, v.Pointer())
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return
+	}
+
+	if isFlatLeaf(v.Type()) {
+		var s string
+		if err := f.conv.convert(reflect.ValueOf(&s).Elem(), v); err == nil {
+			res[prefix] = s
+		}
+
+		return
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Leaves are handled above.
+	case reflect.Struct:
+		f.flattenStruct(v, prefix, res)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			f.flatten(v.Index(i), f.index(prefix, strconv.Itoa(i)), res)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			f.flatten(iter.Value(), f.index(prefix, mapKeyString(iter.Key())), res)
+		}
+	}
+}
+
+func (f flattener) flattenStruct(v reflect.Value, prefix string, res map[string]string) {
+	if !v.CanAddr() {
+		tmp := reflect.New(v.Type()).Elem()
+		tmp.Set(v)
+		v = tmp
+	}
+
+	WalkTaggedFields(v, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		// Values of fields behind nil embedded pointers are not addressable.
+		if !fv.CanAddr() || !fv.CanInterface() {
+			return
+		}
+
+		f.flatten(fv, f.field(prefix, tag), res)
+	}, f.tagName)
+}
+
+// Unflatten decodes a flat map of keys and string values into a structure pointer.
+//
+// It is a counterpart of Flatten, values are converted with Convert.
+// Failed values are reported as FieldError with the flat key as path.
+func Unflatten(m map[string]string, structPtr interface{}, tagName string, options ...func(o *FlattenOptions)) error {
+	v := reflect.ValueOf(structPtr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrNeedPointer
+	}
+
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("%w, %s received", ErrStructExpected, v.Type().String())
+	}
+
+	f := newFlattener(tagName, options)
+
+	return f.unflatten(v, "", m)
+}
+
+// hasKeys checks if map has a key with prefix, or nested keys.
+func (f flattener) hasKeys(prefix string, m map[string]string) bool {
+	if prefix == "" {
+		return true
+	}
+
+	if _, ok := m[prefix]; ok {
+		return true
+	}
+
+	for k := range m {
+		if strings.HasPrefix(k, prefix+f.opts.Separator) || strings.HasPrefix(k, prefix+f.opts.IndexPrefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f flattener) unflatten(v reflect.Value, prefix string, m map[string]string) error {
+	if isFlatLeaf(v.Type()) {
+		s, ok := m[prefix]
+		if !ok {
+			return nil
+		}
+
+		return fieldErr(prefix, f.tagName, reflect.ValueOf(s), v.Type(), f.conv.convert(v, reflect.ValueOf(s)))
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Leaves are handled above.
+	case reflect.Ptr:
+		if !f.hasKeys(prefix, m) {
+			return nil
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+
+		return f.unflatten(v.Elem(), prefix, m)
+	case reflect.Struct:
+		return f.unflattenStruct(v, prefix, m)
+	case reflect.Slice, reflect.Array:
+		return f.unflattenSlice(v, prefix, m)
+	case reflect.Map:
+		return f.unflattenMap(v, prefix, m)
+	}
+
+	return nil
+}
+
+func (f flattener) unflattenStruct(v reflect.Value, prefix string, m map[string]string) error {
+	allocEmbedded(v)
+
+	var errs []error
+
+	WalkTaggedFields(v, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		if !fv.CanSet() {
+			return
+		}
+
+		if err := f.unflatten(fv, f.field(prefix, tag), m); err != nil {
+			errs = append(errs, err)
+		}
+	}, f.tagName)
+
+	return JoinErrors(errs...)
+}
+
+// indexes returns unique slice indexes or map keys found in flat keys after prefix.
+func (f flattener) indexes(prefix string, m map[string]string, leaf bool) []string {
+	prefix += f.opts.IndexPrefix
+
+	var res []string
+
+	seen := map[string]bool{}
+
+	for k := range m {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		idx := k[len(prefix):]
+
+		switch {
+		case f.opts.IndexSuffix != "":
+			if pos := strings.Index(idx, f.opts.IndexSuffix); pos != -1 {
+				idx = idx[:pos]
+			}
+		case !leaf:
+			if pos := strings.Index(idx, f.opts.Separator); pos != -1 {
+				idx = idx[:pos]
+			}
+		}
+
+		if !seen[idx] {
+			seen[idx] = true
+
+			res = append(res, idx)
+		}
+	}
+
+	return res
+}
+
+func (f flattener) unflattenSlice(v reflect.Value, prefix string, m map[string]string) error {
+	var (
+		errs []error
+		max  = -1
+		idxs = map[int]bool{}
+	)
+
+	for _, idx := range f.indexes(prefix, m, isFlatLeaf(v.Type().Elem())) {
+		i, err := strconv.Atoi(idx)
+		if err != nil || i < 0 {
+			continue
+		}
+
+		idxs[i] = true
+
+		if i > max {
+			max = i
+		}
+	}
+
+	if max == -1 {
+		return nil
+	}
+
+	if v.Kind() == reflect.Slice {
+		if v.Len() <= max {
+			s := reflect.MakeSlice(v.Type(), max+1, max+1)
+			reflect.Copy(s, v)
+			v.Set(s)
+		}
+	} else if v.Len() <= max {
+		return fieldErr(prefix, f.tagName, reflect.ValueOf(max), v.Type(),
+			fmt.Errorf("%w: index %d out of range for %s", ErrTypeMismatch, max, v.Type().String()))
+	}
+
+	for i := 0; i <= max; i++ {
+		if !idxs[i] {
+			continue
+		}
+
+		if err := f.unflatten(v.Index(i), f.index(prefix, strconv.Itoa(i)), m); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return JoinErrors(errs...)
+}
+
+func (f flattener) unflattenMap(v reflect.Value, prefix string, m map[string]string) error {
+	var errs []error
+
+	t := v.Type()
+
+	for _, idx := range f.indexes(prefix, m, isFlatLeaf(t.Elem())) {
+		itemPrefix := f.index(prefix, idx)
+
+		key := reflect.New(t.Key()).Elem()
+		if err := f.conv.convert(key, reflect.ValueOf(idx)); err != nil {
+			errs = append(errs, fieldErr(itemPrefix, f.tagName, reflect.ValueOf(idx), t.Key(), err))
+
+			continue
+		}
+
+		val := reflect.New(t.Elem()).Elem()
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(t))
+		} else if existing := v.MapIndex(key); existing.IsValid() {
+			val.Set(existing)
+		}
+
+		if err := f.unflatten(val, itemPrefix, m); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		v.SetMapIndex(key, val)
+	}
+
+	return JoinErrors(errs...)
+}
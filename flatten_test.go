@@ -0,0 +1,129 @@
+package refl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+	"github.com/swaggest/refl/internal/sample"
+)
+
+func TestFlatten(t *testing.T) {
+	s := sample.TestSampleStruct{
+		SimpleFloat64: 1.5,
+		SimpleBool:    true,
+		Sub:           sample.TestSubStruct{SubInt: 5},
+		SubSlice:      []sample.TestSubStruct{{SubInt: 7}, {SubInt: 8}},
+	}
+	s.AnonTypeStruct.FieldOne = 3
+
+	m := refl.Flatten(s, "json")
+	assert.Equal(t, map[string]string{
+		"simple_float64":         "1.5",
+		"simple_bool":            "true",
+		"sub.sample_int":         "5",
+		"sub_slice.0.sample_int": "7",
+		"sub_slice.1.sample_int": "8",
+		"anon_type_struct.int":   "3",
+	}, m)
+
+	var d sample.TestSampleStruct
+
+	require.NoError(t, refl.Unflatten(m, &d, "json"))
+	assert.Equal(t, s, d)
+}
+
+type flatEmbedded struct {
+	ID int `query:"id"`
+}
+
+type flatStruct struct {
+	flatEmbedded
+	Name    *string        `query:"name"`
+	Nested  *flatStruct    `query:"nested"`
+	Tags    []string       `query:"tags"`
+	Labels  map[string]int `query:"labels"`
+	Created time.Time      `query:"created"`
+	Skipped string         `query:"-"`
+	Untag   string
+	Items   map[string]flatSub `query:"items"`
+}
+
+type flatSub struct {
+	Val float64 `query:"val"`
+}
+
+func TestFlatten_options(t *testing.T) {
+	name := "foo"
+	s := flatStruct{
+		Name:    &name,
+		Nested:  &flatStruct{Tags: []string{"a"}},
+		Tags:    []string{"x", "y"},
+		Labels:  map[string]int{"l": 1},
+		Created: time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC),
+		Skipped: "skip",
+		Untag:   "skip",
+		Items:   map[string]flatSub{"k": {Val: 1.5}},
+	}
+	s.ID = 12
+
+	option := func(o *refl.FlattenOptions) {
+		o.Separator = "_"
+		o.IndexPrefix = "["
+		o.IndexSuffix = "]"
+	}
+
+	m := refl.Flatten(&s, "query", option)
+	assert.Equal(t, map[string]string{
+		"id":             "12",
+		"name":           "foo",
+		"nested_id":      "0",
+		"nested_tags[0]": "a",
+		"nested_created": "0001-01-01T00:00:00Z",
+		"tags[0]":        "x",
+		"tags[1]":        "y",
+		"labels[l]":      "1",
+		"created":        "2021-02-03T00:00:00Z",
+		"items[k]_val":   "1.5",
+	}, m)
+
+	var d flatStruct
+
+	require.NoError(t, refl.Unflatten(m, &d, "query", option))
+
+	s.Skipped = ""
+	s.Untag = ""
+	s.Nested.Labels = nil
+	s.Nested.Items = nil
+	assert.Equal(t, s, d)
+}
+
+func TestFlatten_cyclic(t *testing.T) {
+	type node struct {
+		Name string `json:"name"`
+		Next *node  `json:"next"`
+	}
+
+	n := &node{Name: "a", Next: &node{Name: "b"}}
+	n.Next.Next = n
+
+	assert.Equal(t, map[string]string{
+		"name":      "a",
+		"next.name": "b",
+	}, refl.Flatten(n, "json"))
+}
+
+func TestUnflatten_errors(t *testing.T) {
+	var d sample.TestSampleStruct
+
+	err := refl.Unflatten(map[string]string{
+		"simple_bool":            "maybe",
+		"sub_slice.1.sample_int": "abc",
+	}, &d, "json")
+	assert.EqualError(t, err, `simple_bool: strconv.ParseBool: parsing "maybe": invalid syntax, `+
+		`sub_slice.1.sample_int: strconv.ParseInt: parsing "abc": invalid syntax`)
+
+	assert.Equal(t, refl.ErrNeedPointer, refl.Unflatten(nil, d, "json"))
+}
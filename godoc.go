@@ -0,0 +1,213 @@
+package refl
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TypeDoc holds doc comments of a type declaration and its fields.
+type TypeDoc struct {
+	// Type is a doc comment of type declaration.
+	Type string
+
+	// Fields are doc comments of struct fields keyed by path, fields of inline structures
+	// are included, for example "AnonTypeStruct.FieldOne". Trailing line comment is used
+	// if field has no doc comment.
+	Fields map[FieldPath]string
+}
+
+// Description returns `description` tag value or doc comment of a field as a fallback.
+func (d TypeDoc) Description(path FieldPath, tag reflect.StructTag) string {
+	if desc, ok := tag.Lookup("description"); ok {
+		return desc
+	}
+
+	return d.Fields[path]
+}
+
+var packageDocsCache sync.Map
+
+// ReadTypeDoc parses package source of a named type with go/parser and returns doc comments.
+//
+// Package directory is resolved relative to moduleDir that contains go.mod, only packages of that
+// module are supported, other packages are reported with ErrPackageNotInModule.
+// Parsed packages are cached by directory and package name.
+func ReadTypeDoc(t reflect.Type, moduleDir string) (TypeDoc, error) {
+	for t.Kind() == reflect.Ptr && t.Name() == "" {
+		t = t.Elem()
+	}
+
+	if t.Name() == "" || t.PkgPath() == "" {
+		return TypeDoc{}, fmt.Errorf("%w: named type expected, %s received", ErrTypeMismatch, t.String())
+	}
+
+	dir, err := packageDir(t.PkgPath(), moduleDir)
+	if err != nil {
+		return TypeDoc{}, err
+	}
+
+	docs, err := packageDocs(dir, strings.HasSuffix(t.PkgPath(), "_test"))
+	if err != nil {
+		return TypeDoc{}, err
+	}
+
+	d, ok := docs[t.Name()]
+	if !ok {
+		return TypeDoc{}, fmt.Errorf("%w: %s in %s", ErrTypeNotFound, t.Name(), dir)
+	}
+
+	return d, nil
+}
+
+func packageDir(pkgPath, moduleDir string) (string, error) {
+	f, err := os.Open(filepath.Join(moduleDir, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	modulePath := ""
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if strings.HasPrefix(line, "module ") {
+			modulePath = strings.Trim(strings.TrimSpace(line[len("module "):]), `"`)
+
+			break
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+
+	// External test packages are located with the package under test.
+	pkgPath = strings.TrimSuffix(pkgPath, "_test")
+
+	if modulePath == "" || (pkgPath != modulePath && !strings.HasPrefix(pkgPath, modulePath+"/")) {
+		return "", fmt.Errorf("%w: %s, module %s", ErrPackageNotInModule, pkgPath, modulePath)
+	}
+
+	return filepath.Join(moduleDir, filepath.FromSlash(strings.TrimPrefix(pkgPath, modulePath))), nil
+}
+
+// packageDocs returns docs of types declared in directory by package or by its external test package.
+func packageDocs(dir string, testPkg bool) (map[string]TypeDoc, error) {
+	key := dir + ":" + fmt.Sprint(testPkg)
+
+	if cached, ok := packageDocsCache.Load(key); ok {
+		return cached.(map[string]TypeDoc), nil //nolint:forcetypeassert // Cache only has map[string]TypeDoc.
+	}
+
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := map[string]TypeDoc{}
+
+	for name, pkg := range pkgs {
+		if strings.HasSuffix(name, "_test") != testPkg {
+			continue
+		}
+
+		for _, f := range pkg.Files {
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+
+				for _, spec := range gd.Specs {
+					ts, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+
+					d := TypeDoc{Fields: map[FieldPath]string{}}
+
+					d.Type = commentText(ts.Doc)
+					if d.Type == "" && len(gd.Specs) == 1 {
+						d.Type = commentText(gd.Doc)
+					}
+
+					if st, ok := ts.Type.(*ast.StructType); ok {
+						collectFieldDocs(st, "", d.Fields)
+					}
+
+					docs[ts.Name.Name] = d
+				}
+			}
+		}
+	}
+
+	packageDocsCache.Store(key, docs)
+
+	return docs, nil
+}
+
+func collectFieldDocs(st *ast.StructType, path FieldPath, docs map[FieldPath]string) {
+	for _, field := range st.Fields.List {
+		doc := commentText(field.Doc)
+		if doc == "" {
+			doc = commentText(field.Comment)
+		}
+
+		var names []string
+
+		for _, n := range field.Names {
+			names = append(names, n.Name)
+		}
+
+		if len(names) == 0 {
+			names = append(names, embeddedName(field.Type))
+		}
+
+		for _, name := range names {
+			fieldPath := path.Field(name)
+
+			if doc != "" {
+				docs[fieldPath] = doc
+			}
+
+			if inline, ok := field.Type.(*ast.StructType); ok {
+				collectFieldDocs(inline, fieldPath, docs)
+			}
+		}
+	}
+}
+
+func embeddedName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+
+	return ""
+}
+
+func commentText(cg *ast.CommentGroup) string {
+	return strings.TrimSpace(cg.Text())
+}
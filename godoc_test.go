@@ -0,0 +1,84 @@
+package refl_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+	"github.com/swaggest/refl/internal/sample"
+)
+
+// godocSample is documented.
+//
+// It has multiple paragraphs.
+type godocSample struct {
+	// ID is an identifier.
+	ID int `json:"id"`
+
+	Name string `json:"name" description:"Name from tag."` // Name is overridden by tag.
+
+	// Nested has inline fields.
+	Nested struct {
+		// Value is nested.
+		Value float64
+	}
+
+	*godocBase
+
+	Plain bool
+}
+
+type (
+	// godocBase is embedded.
+	godocBase struct{}
+)
+
+// flattener has the same name as a type of package under test.
+type flattener struct{}
+
+func TestReadTypeDoc(t *testing.T) {
+	d, err := refl.ReadTypeDoc(reflect.TypeOf(new(godocSample)), ".")
+	require.NoError(t, err)
+
+	assert.Equal(t, "godocSample is documented.\n\nIt has multiple paragraphs.", d.Type)
+	assert.Equal(t, map[refl.FieldPath]string{
+		"ID":           "ID is an identifier.",
+		"Name":         "Name is overridden by tag.",
+		"Nested":       "Nested has inline fields.",
+		"Nested.Value": "Value is nested.",
+	}, d.Fields)
+
+	sf, _ := reflect.TypeOf(godocSample{}).FieldByName("Name")
+	assert.Equal(t, "Name from tag.", d.Description("Name", sf.Tag))
+
+	sf, _ = reflect.TypeOf(godocSample{}).FieldByName("ID")
+	assert.Equal(t, "ID is an identifier.", d.Description("ID", sf.Tag))
+
+	d, err = refl.ReadTypeDoc(reflect.TypeOf(godocBase{}), ".")
+	require.NoError(t, err)
+	assert.Equal(t, "godocBase is embedded.", d.Type)
+
+	d, err = refl.ReadTypeDoc(reflect.TypeOf(sample.TestSubStruct{}), ".")
+	require.NoError(t, err)
+	assert.Equal(t, "TestSubStruct is a test structure.", d.Type)
+	assert.Empty(t, d.Fields)
+
+	_, err = refl.ReadTypeDoc(reflect.TypeOf(1), ".")
+	assert.True(t, errors.Is(err, refl.ErrTypeMismatch))
+
+	d, err = refl.ReadTypeDoc(reflect.TypeOf(flattener{}), ".")
+	require.NoError(t, err)
+	assert.Equal(t, "flattener has the same name as a type of package under test.", d.Type)
+
+	_, err = refl.ReadTypeDoc(reflect.TypeOf(errors.New("")), ".")
+	assert.True(t, errors.Is(err, refl.ErrPackageNotInModule))
+
+	type local struct{}
+
+	_, err = refl.ReadTypeDoc(reflect.TypeOf(local{}), ".")
+	assert.True(t, errors.Is(err, refl.ErrTypeNotFound))
+	assert.EqualError(t, err, "type not found: local in .")
+}
@@ -2,6 +2,7 @@ package refl
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -31,15 +32,24 @@ func GoType(t reflect.Type) TypeString {
 	}
 
 	if typeRef != ts {
-		s = pkgPath + "::" + t.String()
+		if pkgPath != "" {
+			s = pkgPath + "::" + ts
+		} else {
+			// Unnamed types, for example func(string) error or struct{}, have no import path.
+			s = ts
+		}
 	}
 
 	//nolint:exhaustive // This switch only looks into specific kind.
 	switch t.Kind() {
-	case reflect.Slice, reflect.Array:
+	case reflect.Slice:
 		if pkgPath == "" {
 			return "[]" + GoType(t.Elem())
 		}
+	case reflect.Array:
+		if pkgPath == "" {
+			return TypeString("["+strconv.Itoa(t.Len())+"]") + GoType(t.Elem())
+		}
 	case reflect.Ptr:
 		if pkgPath == "" {
 			return "*" + GoType(t.Elem())
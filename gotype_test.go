@@ -42,4 +42,8 @@ func TestGoType(t *testing.T) {
 	assert.Equal(t, refl.TypeString("github.com/swaggest/refl_test.NamedSlice"), refl.GoType(reflect.TypeOf(NamedSlice{})))
 	assert.Equal(t, refl.TypeString("[]string"), refl.GoType(reflect.TypeOf([]string{})))
 	assert.Equal(t, refl.TypeString("github.com/swaggest/refl_test.NamedMap"), refl.GoType(reflect.TypeOf(NamedMap{})))
+	assert.Equal(t, refl.TypeString("[2]*github.com/swaggest/refl_test.NamedSlice"), refl.GoType(reflect.TypeOf([2]*NamedSlice{})))
+	assert.Equal(t, refl.TypeString("map[string]interface {}"), refl.GoType(reflect.TypeOf(map[string]interface{}{})))
+	assert.Equal(t, refl.TypeString("func(int) error"), refl.GoType(reflect.TypeOf(func(int) error { return nil })))
+	assert.Equal(t, refl.TypeString("struct {}"), refl.GoType(reflect.TypeOf(struct{}{})))
 }
@@ -0,0 +1,187 @@
+package refl
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// DecodeHeader decodes http.Header into a structure pointer using `header` field tags.
+//
+// Tag names are canonicalized, so `header:"x-request-id"` matches "X-Request-Id".
+// Slices receive all values of a header, values with commas are split into separate items.
+// Values are converted as in Convert, failed values are reported as FieldError with the header as path.
+func DecodeHeader(h http.Header, structPtr interface{}, options ...func(o *ValuesOptions)) error {
+	return decodeStrings(structPtr, "header", func(name string) []string {
+		return h.Values(name)
+	}, options)
+}
+
+// DecodeCookies decodes cookies into a structure pointer using `cookie` field tags.
+//
+// Slices receive values of all cookies with the same name.
+func DecodeCookies(cookies []*http.Cookie, structPtr interface{}, options ...func(o *ValuesOptions)) error {
+	return decodeStrings(structPtr, "cookie", func(name string) []string {
+		var res []string
+
+		for _, c := range cookies {
+			if c.Name == name {
+				res = append(res, c.Value)
+			}
+		}
+
+		return res
+	}, options)
+}
+
+// DecodeRequest decodes headers and cookies of http.Request into a structure pointer
+// using `header` and `cookie` field tags.
+//
+// Query parameters can be decoded with DecodeValues.
+func DecodeRequest(r *http.Request, structPtr interface{}, options ...func(o *ValuesOptions)) error {
+	if err := DecodeHeader(r.Header, structPtr, options...); err != nil {
+		return err
+	}
+
+	return DecodeCookies(r.Cookies(), structPtr, options...)
+}
+
+// EncodeHeader sets values of structure fields with `header` tags to http.Header.
+//
+// Slices are added as multiple values, nil pointers are skipped. Zero values of fields tagged
+// with "omitempty" option are skipped.
+func EncodeHeader(v interface{}, h http.Header, options ...func(o *ValuesOptions)) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+
+	opts := ValuesOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	conv := newConverter(opts.ConvertOptions)
+
+	WalkTaggedFields(rv, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		if !fv.CanInterface() {
+			return
+		}
+
+		if hasTagOption(sf.Tag.Get("header"), "omitempty") && isZero(fv) {
+			return
+		}
+
+		for fv.Kind() == reflect.Ptr || fv.Kind() == reflect.Interface {
+			if fv.IsNil() {
+				return
+			}
+
+			fv = fv.Elem()
+		}
+
+		items := []reflect.Value{fv}
+
+		if !isFlatLeaf(fv.Type()) {
+			if (fv.Kind() != reflect.Slice && fv.Kind() != reflect.Array) || !isFlatLeaf(fv.Type().Elem()) {
+				return
+			}
+
+			items = items[:0]
+			for i := 0; i < fv.Len(); i++ {
+				items = append(items, fv.Index(i))
+			}
+		}
+
+		h.Del(tag)
+
+		for _, item := range items {
+			var s string
+			if err := conv.convert(reflect.ValueOf(&s).Elem(), item); err == nil {
+				h.Add(tag, s)
+			}
+		}
+	}, "header")
+}
+
+// decodeStrings decodes string values found by tag names into fields of a structure pointer.
+func decodeStrings(
+	structPtr interface{},
+	tagName string,
+	lookup func(name string) []string,
+	options []func(o *ValuesOptions),
+) error {
+	v := reflect.ValueOf(structPtr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrNeedPointer
+	}
+
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("%w, %s received", ErrStructExpected, v.Type().String())
+	}
+
+	opts := ValuesOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	conv := newConverter(opts.ConvertOptions)
+
+	allocEmbedded(v)
+
+	var errs []error
+
+	WalkTaggedFields(v, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		if !fv.CanSet() {
+			return
+		}
+
+		vals := lookup(tag)
+		if len(vals) == 0 {
+			return
+		}
+
+		var src reflect.Value
+
+		t := DeepIndirect(fv.Type())
+
+		switch {
+		case isFlatLeaf(t):
+			src = reflect.ValueOf(vals[0])
+		case (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && isFlatLeaf(t.Elem()):
+			if tagName == "header" {
+				vals = splitHeaderValues(vals)
+			}
+
+			src = reflect.ValueOf(vals)
+		default:
+			return
+		}
+
+		if err := conv.convert(fv, src); err != nil {
+			errs = append(errs, fieldErr(tag, tagName, src, fv.Type(), err))
+		}
+	}, tagName)
+
+	return JoinErrors(errs...)
+}
+
+func splitHeaderValues(vals []string) []string {
+	res := make([]string, 0, len(vals))
+
+	for _, v := range vals {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				res = append(res, item)
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,76 @@
+package refl_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type requestHeaders struct {
+	RequestID string        `header:"x-request-id"`
+	Accept    []string      `header:"Accept"`
+	Timeout   time.Duration `header:"X-Timeout"`
+	Limit     *int          `header:"X-Limit"`
+	Missing   *string       `header:"X-Missing,omitempty"`
+	Session   string        `cookie:"session"`
+	Prefs     []string      `cookie:"pref"`
+}
+
+func TestDecodeRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-Id", "abc")
+	r.Header.Add("Accept", "text/html, application/json")
+	r.Header.Add("Accept", "*/*")
+	r.Header.Set("X-Timeout", "5s")
+	r.Header.Set("X-Limit", "10")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "s1"})
+	r.AddCookie(&http.Cookie{Name: "pref", Value: "a"})
+	r.AddCookie(&http.Cookie{Name: "pref", Value: "b"})
+
+	var h requestHeaders
+
+	require.NoError(t, refl.DecodeRequest(r, &h))
+
+	assert.Equal(t, "abc", h.RequestID)
+	assert.Equal(t, []string{"text/html", "application/json", "*/*"}, h.Accept)
+	assert.Equal(t, 5*time.Second, h.Timeout)
+	require.NotNil(t, h.Limit)
+	assert.Equal(t, 10, *h.Limit)
+	assert.Nil(t, h.Missing)
+	assert.Equal(t, "s1", h.Session)
+	assert.Equal(t, []string{"a", "b"}, h.Prefs)
+
+	r.Header.Set("X-Limit", "abc")
+	assert.EqualError(t, refl.DecodeHeader(r.Header, &h),
+		`X-Limit: strconv.ParseInt: parsing "abc": invalid syntax`)
+
+	assert.Equal(t, refl.ErrNeedPointer, refl.DecodeCookies(nil, h))
+}
+
+func TestEncodeHeader(t *testing.T) {
+	limit := 3
+	h := requestHeaders{
+		RequestID: "abc",
+		Accept:    []string{"text/html", "*/*"},
+		Timeout:   time.Minute,
+		Limit:     &limit,
+		Session:   "s1",
+	}
+
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Accept", "text/plain")
+
+	refl.EncodeHeader(h, rec.Header())
+
+	assert.Equal(t, http.Header{
+		"X-Request-Id": {"abc"},
+		"Accept":       {"text/html", "*/*"},
+		"X-Timeout":    {"1m0s"},
+		"X-Limit":      {"3"},
+	}, rec.Header())
+}
@@ -0,0 +1,180 @@
+package refl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MethodMismatch describes a method that is present, but has a different signature.
+type MethodMismatch struct {
+	Name string
+	Want string
+	Have string
+}
+
+// ImplementsReport describes whether a type implements an interface.
+type ImplementsReport struct {
+	Type      reflect.Type
+	Interface reflect.Type
+
+	// ByValue is true if Type implements Interface.
+	ByValue bool
+
+	// ByPointer is true if pointer to Type implements Interface.
+	ByPointer bool
+
+	// Missing lists interface methods that are absent in both Type and pointer to Type.
+	Missing []string
+
+	// Mismatched lists methods that are present with a different signature.
+	Mismatched []MethodMismatch
+
+	// PointerReceiver lists methods that are only available with pointer receiver.
+	PointerReceiver []string
+}
+
+// Err returns error that describes why neither Type nor pointer to Type implement Interface, or nil.
+func (r ImplementsReport) Err() error {
+	if r.ByValue || r.ByPointer {
+		return nil
+	}
+
+	var reasons []string
+
+	for _, m := range r.Missing {
+		reasons = append(reasons, "missing method "+m)
+	}
+
+	for _, m := range r.Mismatched {
+		reasons = append(reasons, "method "+m.Name+" has signature "+m.Have+", expected "+m.Want)
+	}
+
+	return fmt.Errorf("%s %w %s: %s", GoType(r.Type), ErrNotImplemented, GoType(r.Interface), strings.Join(reasons, ", "))
+}
+
+// Implements checks if type or a pointer to it implements an interface.
+//
+// If t is a pointer, its element type is checked.
+//
+//	r := refl.Implements(reflect.TypeOf(MyService{}), reflect.TypeOf((*Service)(nil)).Elem())
+//	if err := r.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+func Implements(t, iface reflect.Type) ImplementsReport {
+	if iface.Kind() != reflect.Interface {
+		panic("interface type expected, " + iface.String() + " received")
+	}
+
+	if t.Kind() == reflect.Ptr && t.Name() == "" {
+		t = t.Elem()
+	}
+
+	r := ImplementsReport{
+		Type:      t,
+		Interface: iface,
+		ByValue:   t.Implements(iface),
+	}
+
+	pt := t
+	if t.Kind() != reflect.Interface {
+		pt = reflect.PtrTo(t)
+		r.ByPointer = pt.Implements(iface)
+	}
+
+	for i := 0; i < iface.NumMethod(); i++ {
+		im := iface.Method(i)
+
+		m, found := pt.MethodByName(im.Name)
+		if !found {
+			r.Missing = append(r.Missing, im.Name)
+
+			continue
+		}
+
+		mt := m.Type
+		if pt.Kind() != reflect.Interface {
+			mt = methodWithoutReceiver(mt)
+		}
+
+		if !sameSignature(mt, im.Type) {
+			r.Mismatched = append(r.Mismatched, MethodMismatch{
+				Name: im.Name,
+				Want: signature(im.Name, im.Type, 0),
+				Have: signature(im.Name, m.Type, m.Type.NumIn()-mt.NumIn()),
+			})
+
+			continue
+		}
+
+		if _, found := t.MethodByName(im.Name); !found {
+			r.PointerReceiver = append(r.PointerReceiver, im.Name)
+		}
+	}
+
+	return r
+}
+
+// methodWithoutReceiver returns function type of a method without the receiver argument.
+func methodWithoutReceiver(mt reflect.Type) reflect.Type {
+	in := make([]reflect.Type, 0, mt.NumIn()-1)
+	for i := 1; i < mt.NumIn(); i++ {
+		in = append(in, mt.In(i))
+	}
+
+	out := make([]reflect.Type, 0, mt.NumOut())
+	for i := 0; i < mt.NumOut(); i++ {
+		out = append(out, mt.Out(i))
+	}
+
+	return reflect.FuncOf(in, out, mt.IsVariadic())
+}
+
+func sameSignature(a, b reflect.Type) bool {
+	if a.NumIn() != b.NumIn() || a.NumOut() != b.NumOut() || a.IsVariadic() != b.IsVariadic() {
+		return false
+	}
+
+	for i := 0; i < a.NumIn(); i++ {
+		if a.In(i) != b.In(i) {
+			return false
+		}
+	}
+
+	for i := 0; i < a.NumOut(); i++ {
+		if a.Out(i) != b.Out(i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// signature renders function type as method signature with GoType names, skipping first skipIn arguments.
+func signature(name string, ft reflect.Type, skipIn int) string {
+	in := make([]string, 0, ft.NumIn())
+
+	for i := skipIn; i < ft.NumIn(); i++ {
+		if ft.IsVariadic() && i == ft.NumIn()-1 {
+			in = append(in, "..."+string(GoType(ft.In(i).Elem())))
+		} else {
+			in = append(in, string(GoType(ft.In(i))))
+		}
+	}
+
+	s := name + "(" + strings.Join(in, ", ") + ")"
+
+	out := make([]string, 0, ft.NumOut())
+	for i := 0; i < ft.NumOut(); i++ {
+		out = append(out, string(GoType(ft.Out(i))))
+	}
+
+	switch len(out) {
+	case 0:
+		return s
+	case 1:
+		return s + " " + out[0]
+	default:
+		return s + " (" + strings.Join(out, ", ") + ")"
+	}
+}
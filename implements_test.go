@@ -0,0 +1,103 @@
+package refl_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/refl"
+)
+
+type registrar interface {
+	Register(name string, opts ...int) error
+	Close() error
+	Name() string
+}
+
+type valueRegistrar struct{}
+
+func (valueRegistrar) Register(string, ...int) error { return nil }
+func (valueRegistrar) Close() error                  { return nil }
+func (valueRegistrar) Name() string                  { return "" }
+
+type ptrRegistrar struct{}
+
+func (*ptrRegistrar) Register(string, ...int) error { return nil }
+func (ptrRegistrar) Close() error                   { return nil }
+func (*ptrRegistrar) Name() string                  { return "" }
+
+type brokenRegistrar struct{}
+
+func (brokenRegistrar) Register(name []string) (int, error) { return 0, nil }
+func (*brokenRegistrar) Close() error                       { return nil }
+
+type handler interface {
+	Do(cb func(string), v interface{}, m map[string]interface{}) [3]int
+}
+
+type brokenHandler struct{}
+
+func (brokenHandler) Do(cb func(string), v interface{}, m map[string]interface{}) []int { return nil }
+
+func TestImplements(t *testing.T) {
+	iface := reflect.TypeOf((*registrar)(nil)).Elem()
+
+	r := refl.Implements(reflect.TypeOf(valueRegistrar{}), iface)
+	assert.True(t, r.ByValue)
+	assert.True(t, r.ByPointer)
+	assert.Empty(t, r.PointerReceiver)
+	assert.NoError(t, r.Err())
+
+	r = refl.Implements(reflect.TypeOf(new(ptrRegistrar)), iface)
+	assert.Equal(t, reflect.TypeOf(ptrRegistrar{}), r.Type)
+	assert.False(t, r.ByValue)
+	assert.True(t, r.ByPointer)
+	assert.Equal(t, []string{"Name", "Register"}, r.PointerReceiver)
+	assert.NoError(t, r.Err())
+
+	r = refl.Implements(reflect.TypeOf(brokenRegistrar{}), iface)
+	assert.False(t, r.ByValue)
+	assert.False(t, r.ByPointer)
+	assert.Equal(t, []string{"Name"}, r.Missing)
+	assert.Equal(t, []refl.MethodMismatch{{
+		Name: "Register",
+		Want: "Register(string, ...int) error",
+		Have: "Register([]string) (int, error)",
+	}}, r.Mismatched)
+	assert.Equal(t, []string{"Close"}, r.PointerReceiver)
+
+	err := r.Err()
+	assert.True(t, errors.Is(err, refl.ErrNotImplemented))
+	assert.EqualError(t, err, "github.com/swaggest/refl_test.brokenRegistrar does not implement "+
+		"github.com/swaggest/refl_test.registrar: missing method Name, "+
+		"method Register has signature Register([]string) (int, error), expected Register(string, ...int) error")
+
+	r = refl.Implements(reflect.TypeOf(brokenHandler{}), reflect.TypeOf((*handler)(nil)).Elem())
+	assert.EqualError(t, r.Err(), "github.com/swaggest/refl_test.brokenHandler does not implement "+
+		"github.com/swaggest/refl_test.handler: method Do has signature "+
+		"Do(func(string), interface {}, map[string]interface {}) []int, "+
+		"expected Do(func(string), interface {}, map[string]interface {}) [3]int")
+
+	r = refl.Implements(reflect.TypeOf((*io.ReadCloser)(nil)).Elem(), reflect.TypeOf((*io.Closer)(nil)).Elem())
+	assert.True(t, r.ByValue)
+	assert.False(t, r.ByPointer)
+
+	r = refl.Implements(reflect.TypeOf((*io.Closer)(nil)).Elem(), reflect.TypeOf((*io.ReadCloser)(nil)).Elem())
+	assert.Equal(t, []string{"Read"}, r.Missing)
+
+	assert.Panics(t, func() {
+		refl.Implements(reflect.TypeOf(valueRegistrar{}), reflect.TypeOf(valueRegistrar{}))
+	})
+}
+
+func ExampleImplements() {
+	r := refl.Implements(reflect.TypeOf(brokenRegistrar{}), reflect.TypeOf((*fmt.Stringer)(nil)).Elem())
+
+	fmt.Println(r.Err())
+
+	// Output:
+	// github.com/swaggest/refl_test.brokenRegistrar does not implement fmt.Stringer: missing method String
+}
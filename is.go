@@ -1,7 +1,6 @@
 package refl
 
 import (
-	"fmt"
 	"reflect"
 )
 
@@ -60,27 +59,12 @@ func FindEmbeddedSliceOrMap(i interface{}) reflect.Type {
 		return nil
 	}
 
-	t := DeepIndirect(reflect.TypeOf(i))
-
-	if t.Kind() != reflect.Struct {
+	found := FindEmbedded(reflect.TypeOf(i), FieldKind(reflect.Slice, reflect.Map, reflect.Array))
+	if len(found) == 0 {
 		return nil
 	}
 
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		if f.Anonymous {
-			v := reflect.Zero(f.Type).Interface()
-			if IsSliceOrMap(v) {
-				return f.Type
-			}
-
-			if t := FindEmbeddedSliceOrMap(v); t != nil {
-				return t
-			}
-		}
-	}
-
-	return nil
+	return found[0].Field.Type
 }
 
 // IsZero reports whether v is the zero value for its type.
@@ -90,72 +74,3 @@ func FindEmbeddedSliceOrMap(i interface{}) reflect.Type {
 func IsZero(v reflect.Value) bool {
 	return v.IsZero()
 }
-
-// As unwraps interface value to find value assignable to target.
-func As(v interface{}, target interface{}) bool {
-	if v == nil {
-		return false
-	}
-
-	rvv := reflect.ValueOf(v)
-	rvt := reflect.TypeOf(v)
-
-	rtv := reflect.ValueOf(target)
-	rtt := rtv.Type()
-
-	if rtt.Kind() != reflect.Ptr || rtv.IsNil() {
-		panic("target must be a non-nil pointer")
-	}
-
-	targetType := rtt.Elem()
-
-	for {
-		if rvt.AssignableTo(targetType) {
-			rtv.Elem().Set(rvv)
-
-			return true
-		}
-
-		if rvt.Kind() != reflect.Ptr && rvt.Kind() != reflect.Interface {
-			break
-		}
-
-		rvv = rvv.Elem()
-		if rvv.Interface() == nil {
-			break
-		}
-
-		rvt = rvv.Type()
-	}
-
-	return false
-}
-
-// NoEmptyFields checks structure (or a pointer to it) for potentially uninitialized fields.
-// Fields with zero values are considered uninitialized.
-func NoEmptyFields(l interface{}) error {
-	v := reflect.ValueOf(l)
-
-	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
-		v = v.Elem()
-	}
-
-	if v.Kind() != reflect.Struct {
-		return fmt.Errorf("%w, %s received", ErrStructExpected, v.Type().String())
-	}
-
-	var missing []string
-
-	for i := 0; i < v.NumField(); i++ {
-		f := v.Field(i)
-		if f.IsZero() {
-			missing = append(missing, v.Type().Field(i).Name)
-		}
-	}
-
-	if len(missing) > 0 {
-		return fmt.Errorf("%w: %v", ErrEmptyFields, missing)
-	}
-
-	return nil
-}
@@ -0,0 +1,218 @@
+package refl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Merge strategies for `merge` field tag.
+const (
+	// MergeNonZero overrides destination with non-zero source value, nested structures are merged recursively.
+	// This is default strategy.
+	MergeNonZero = "nonzero"
+
+	// MergeOverride always overrides destination with source value, even if it is zero.
+	MergeOverride = "override"
+
+	// MergeAppend appends source slice to destination slice.
+	MergeAppend = "append"
+
+	// MergeUnion adds source map entries to destination map, source wins on key collision.
+	MergeUnion = "union"
+
+	// MergeKeep keeps non-zero destination value, zero destination is filled from source.
+	MergeKeep = "keep"
+)
+
+// MergeOptions controls advanced behavior of Merge.
+type MergeOptions struct {
+	// TagName is a name of field tag that defines merge strategy, default "merge".
+	// Tag value "-" skips the field.
+	TagName string
+
+	// ZeroPointersAsSet makes non-nil source pointers to zero values override destination.
+	// By default, pointers to zero values are ignored, same as nil pointers.
+	ZeroPointersAsSet bool
+
+	// OnConflict is called when both destination and source have different non-zero values and
+	// destination is overridden. Path is a dot-separated list of Go field names, map entries merged
+	// with MergeUnion have key in brackets, for example "Labels[env]".
+	OnConflict func(path string, dst, src interface{})
+}
+
+// Merge overlays fields of src structure (or a pointer to it) onto dst pointer to a structure of the same type.
+//
+// Per-field strategy is defined with `merge` tag, see MergeNonZero, MergeOverride,
+// MergeAppend, MergeUnion and MergeKeep. Unexported fields are skipped.
+// Unknown strategies are reported with FieldError.
+//
+//	type Config struct {
+//		Name    string
+//		Plugins []string          `merge:"append"`
+//		Labels  map[string]string `merge:"union"`
+//		Debug   bool              `merge:"override"`
+//	}
+func Merge(dst, src interface{}, options ...func(o *MergeOptions)) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return ErrNeedPointer
+	}
+
+	dv = dv.Elem()
+	if dv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w, %s received", ErrStructExpected, dv.Type().String())
+	}
+
+	sv := reflect.ValueOf(src)
+	for sv.Kind() == reflect.Ptr && !sv.IsNil() {
+		sv = sv.Elem()
+	}
+
+	if !sv.IsValid() {
+		return fmt.Errorf("%w: %s expected, nil received", ErrTypeMismatch, dv.Type().String())
+	}
+
+	if sv.Type() != dv.Type() {
+		return fmt.Errorf("%w: %s expected, %s received", ErrTypeMismatch, dv.Type().String(), sv.Type().String())
+	}
+
+	m := merger{}
+	for _, option := range options {
+		option(&m.opts)
+	}
+
+	if m.opts.TagName == "" {
+		m.opts.TagName = "merge"
+	}
+
+	m.mergeStruct(dv, sv, "")
+
+	return JoinErrors(m.errs...)
+}
+
+type merger struct {
+	opts MergeOptions
+	errs []error
+}
+
+func (m *merger) mergeStruct(dst, src reflect.Value, prefix string) {
+	t := dst.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		strategy := strings.Split(sf.Tag.Get(m.opts.TagName), ",")[0]
+
+		switch strategy {
+		case "-":
+			continue
+		case "", MergeNonZero, MergeOverride, MergeAppend, MergeUnion, MergeKeep:
+		default:
+			m.errs = append(m.errs, FieldError{
+				Path:  prefix + sf.Name,
+				Tag:   m.opts.TagName,
+				Value: strategy,
+				Type:  sf.Type,
+				Err:   fmt.Errorf("unknown merge strategy %q", strategy),
+			})
+
+			continue
+		}
+
+		m.merge(dst.Field(i), src.Field(i), strategy, prefix+sf.Name)
+	}
+}
+
+func (m *merger) merge(dst, src reflect.Value, strategy, path string) {
+	switch {
+	case strategy == MergeOverride:
+		m.set(dst, src, path)
+	case strategy == MergeKeep:
+		if isZero(dst) {
+			dst.Set(src)
+		}
+	case strategy == MergeAppend && dst.Kind() == reflect.Slice:
+		if src.Len() > 0 {
+			s := reflect.MakeSlice(dst.Type(), 0, dst.Len()+src.Len())
+			dst.Set(reflect.AppendSlice(reflect.AppendSlice(s, dst), src))
+		}
+	case strategy == MergeUnion && dst.Kind() == reflect.Map:
+		m.union(dst, src, path)
+	case dst.Kind() == reflect.Struct && !hasIsZero(dst.Type()):
+		m.mergeStruct(dst, src, path+".")
+	case dst.Kind() == reflect.Ptr:
+		m.mergePtr(dst, src, path)
+	default:
+		if !isZero(src) {
+			m.set(dst, src, path)
+		}
+	}
+}
+
+func (m *merger) mergePtr(dst, src reflect.Value, path string) {
+	if src.IsNil() {
+		return
+	}
+
+	if dst.IsNil() {
+		dst.Set(src)
+
+		return
+	}
+
+	if src.Elem().Kind() == reflect.Struct && !hasIsZero(src.Elem().Type()) {
+		// Destination is copied to avoid mutation of structures that could be shared with other values.
+		n := reflect.New(dst.Type().Elem())
+		n.Elem().Set(dst.Elem())
+		m.mergeStruct(n.Elem(), src.Elem(), path+".")
+		dst.Set(n)
+
+		return
+	}
+
+	if m.opts.ZeroPointersAsSet || !isZero(src.Elem()) {
+		m.set(dst, src, path)
+	}
+}
+
+func (m *merger) union(dst, src reflect.Value, path string) {
+	if src.Len() == 0 {
+		return
+	}
+
+	// New map is created to avoid mutation of maps that could be shared with other values.
+	u := reflect.MakeMapWithSize(dst.Type(), dst.Len()+src.Len())
+
+	iter := dst.MapRange()
+	for iter.Next() {
+		u.SetMapIndex(iter.Key(), iter.Value())
+	}
+
+	iter = src.MapRange()
+	for iter.Next() {
+		if prev := u.MapIndex(iter.Key()); prev.IsValid() {
+			m.conflict(string(FieldPath(path).Key(iter.Key())), prev, iter.Value())
+		}
+
+		u.SetMapIndex(iter.Key(), iter.Value())
+	}
+
+	dst.Set(u)
+}
+
+func (m *merger) set(dst, src reflect.Value, path string) {
+	m.conflict(path, dst, src)
+
+	dst.Set(src)
+}
+
+// conflict reports overridden destination value to OnConflict.
+func (m *merger) conflict(path string, dst, src reflect.Value) {
+	if m.opts.OnConflict != nil && !isZero(dst) && !isZero(src) && !reflect.DeepEqual(dst.Interface(), src.Interface()) {
+		m.opts.OnConflict(path, dst.Interface(), src.Interface())
+	}
+}
@@ -0,0 +1,114 @@
+package refl_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type mergeDB struct {
+	DSN     string
+	Timeout time.Duration
+}
+
+type mergeConfig struct {
+	Name     string
+	Debug    bool              `merge:"override"`
+	Plugins  []string          `merge:"append"`
+	Labels   map[string]string `merge:"union"`
+	Owner    string            `merge:"keep"`
+	Ignored  string            `merge:"-"`
+	DB       mergeDB
+	Replica  *mergeDB
+	Verbose  *bool
+	Started  time.Time
+	internal string
+}
+
+func TestMerge(t *testing.T) {
+	defaults := mergeConfig{
+		Name:    "app",
+		Debug:   true,
+		Plugins: []string{"a"},
+		Labels:  map[string]string{"env": "dev", "team": "core"},
+		DB:      mergeDB{DSN: "localhost", Timeout: time.Second},
+		Replica: &mergeDB{DSN: "replica"},
+		Verbose: new(bool),
+	}
+
+	*defaults.Verbose = true
+
+	file := mergeConfig{
+		Owner:    "alice",
+		Ignored:  "ignored",
+		Plugins:  []string{"b"},
+		Labels:   map[string]string{"env": "prod"},
+		DB:       mergeDB{Timeout: 5 * time.Second},
+		Replica:  &mergeDB{Timeout: time.Minute},
+		Verbose:  new(bool),
+		Started:  time.Unix(100, 0),
+		internal: "internal",
+	}
+
+	cfg := defaults
+
+	var conflicts []string
+
+	require.NoError(t, refl.Merge(&cfg, file, func(o *refl.MergeOptions) {
+		o.OnConflict = func(path string, dst, src interface{}) {
+			conflicts = append(conflicts, path)
+		}
+	}))
+
+	assert.Equal(t, "app", cfg.Name)
+	assert.False(t, cfg.Debug)
+	assert.Equal(t, []string{"a", "b"}, cfg.Plugins)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "core"}, cfg.Labels)
+	assert.Equal(t, map[string]string{"env": "dev", "team": "core"}, defaults.Labels)
+	assert.Equal(t, "alice", cfg.Owner)
+	assert.Empty(t, cfg.Ignored)
+	assert.Empty(t, cfg.internal)
+	assert.Equal(t, mergeDB{DSN: "localhost", Timeout: 5 * time.Second}, cfg.DB)
+	assert.Equal(t, &mergeDB{DSN: "replica", Timeout: time.Minute}, cfg.Replica)
+	assert.Equal(t, &mergeDB{DSN: "replica"}, defaults.Replica)
+	assert.True(t, *cfg.Verbose)
+	assert.Equal(t, time.Unix(100, 0), cfg.Started)
+	assert.Equal(t, []string{"Labels[env]", "DB.Timeout"}, conflicts)
+
+	require.NoError(t, refl.Merge(&cfg, &mergeConfig{Owner: "bob", Verbose: new(bool)}, func(o *refl.MergeOptions) {
+		o.ZeroPointersAsSet = true
+	}))
+
+	assert.Equal(t, "alice", cfg.Owner)
+	assert.False(t, *cfg.Verbose)
+}
+
+func TestMerge_errors(t *testing.T) {
+	cfg := mergeConfig{}
+
+	assert.True(t, errors.Is(refl.Merge(cfg, cfg), refl.ErrNeedPointer))
+	assert.EqualError(t, refl.Merge(cfg, cfg), "can not take address of structure, please pass a pointer")
+	assert.True(t, errors.Is(refl.Merge(new(int), 1), refl.ErrStructExpected))
+	assert.EqualError(t, refl.Merge(&cfg, mergeDB{}),
+		"type mismatch: refl_test.mergeConfig expected, refl_test.mergeDB received")
+	assert.EqualError(t, refl.Merge(&cfg, nil),
+		"type mismatch: refl_test.mergeConfig expected, nil received")
+
+	type typo struct {
+		Plugins []string `merge:"apend"`
+	}
+
+	dst := typo{Plugins: []string{"a"}}
+	err := refl.Merge(&dst, typo{Plugins: []string{"b"}})
+	assert.EqualError(t, err, `Plugins: unknown merge strategy "apend"`)
+
+	var fe refl.FieldError
+
+	require.True(t, errors.As(err, &fe))
+	assert.Equal(t, "apend", fe.Value)
+	assert.Equal(t, []string{"a"}, dst.Plugins)
+}
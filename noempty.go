@@ -0,0 +1,179 @@
+package refl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// EmptyFieldsError describes fields with zero values.
+//
+// It matches ErrEmptyFields with errors.Is.
+type EmptyFieldsError struct {
+	// Fields lists paths of empty fields, for example "DB.DSN".
+	Fields []string
+}
+
+// Error implements error.
+func (e EmptyFieldsError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrEmptyFields, e.Fields)
+}
+
+// Unwrap returns ErrEmptyFields.
+func (e EmptyFieldsError) Unwrap() error {
+	return ErrEmptyFields
+}
+
+// NoEmptyFieldsOptions controls advanced behavior of NoEmptyFields.
+type NoEmptyFieldsOptions struct {
+	// Recursive enables checks of fields in nested structures and pointers to structures.
+	// Unexported fields of nested structures are not checked.
+	Recursive bool
+
+	// TagName is a name of field tag that controls the check, default "refl".
+	//
+	// Tag value "-" skips the field, "optional" allows zero value,
+	// "shallow" disables recursive check of a non-zero field.
+	TagName string
+}
+
+// NoEmptyFieldsRecursive enables checks of nested structures.
+func NoEmptyFieldsRecursive(o *NoEmptyFieldsOptions) {
+	o.Recursive = true
+}
+
+// NoEmptyFields checks structure (or a pointer to it) for potentially uninitialized fields.
+// Fields with zero values are considered uninitialized.
+//
+// Values that have `IsZero() bool` method (for example time.Time) are checked with that method.
+//
+// Returned error is EmptyFieldsError.
+func NoEmptyFields(l interface{}, options ...func(o *NoEmptyFieldsOptions)) error {
+	v := reflect.ValueOf(l)
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		received := "nil"
+		if v.IsValid() {
+			received = v.Type().String()
+		}
+
+		return fmt.Errorf("%w, %s received", ErrStructExpected, received)
+	}
+
+	opts := NoEmptyFieldsOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	if opts.TagName == "" {
+		opts.TagName = "refl"
+	}
+
+	var missing []string
+
+	visiting := map[uintptr]bool{}
+
+	if rv := reflect.ValueOf(l); rv.Kind() == reflect.Ptr {
+		visiting[rv.Pointer()] = true
+	}
+
+	noEmptyFields(v, opts, "", &missing, visiting)
+
+	if len(missing) > 0 {
+		return EmptyFieldsError{Fields: missing}
+	}
+
+	return nil
+}
+
+// noEmptyFields checks fields of structure, visiting holds addresses of pointers that are being descended
+// to stop on cyclic values.
+func noEmptyFields(v reflect.Value, opts NoEmptyFieldsOptions, prefix string, missing *[]string, visiting map[uintptr]bool) {
+	t := v.Type()
+
+	for i := 0; i < v.NumField(); i++ {
+		sf := t.Field(i)
+
+		if prefix != "" && sf.PkgPath != "" {
+			continue
+		}
+
+		tag := strings.Split(sf.Tag.Get(opts.TagName), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		f := v.Field(i)
+		path := prefix + sf.Name
+
+		if isZero(f) {
+			if tag != "optional" {
+				*missing = append(*missing, path)
+			}
+
+			continue
+		}
+
+		if !opts.Recursive || tag == "shallow" || hasIsZero(f.Type()) {
+			continue
+		}
+
+		var ptr uintptr
+
+		for f.Kind() == reflect.Ptr && !f.IsNil() {
+			ptr = f.Pointer()
+			f = f.Elem()
+		}
+
+		if f.Kind() != reflect.Struct {
+			continue
+		}
+
+		if ptr == 0 {
+			noEmptyFields(f, opts, path+".", missing, visiting)
+
+			continue
+		}
+
+		if !visiting[ptr] {
+			visiting[ptr] = true
+			noEmptyFields(f, opts, path+".", missing, visiting)
+			delete(visiting, ptr)
+		}
+	}
+}
+
+var isZeroer = reflect.TypeOf((*interface{ IsZero() bool })(nil)).Elem()
+
+func hasIsZero(t reflect.Type) bool {
+	return t.Implements(isZeroer) || (t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(isZeroer))
+}
+
+// isZero checks value with `IsZero() bool` method if available or with reflect.Value.IsZero.
+func isZero(v reflect.Value) bool {
+	if !v.CanInterface() {
+		return v.IsZero()
+	}
+
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+	}
+
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		return z.IsZero()
+	}
+
+	if v.CanAddr() {
+		if z, ok := v.Addr().Interface().(interface{ IsZero() bool }); ok {
+			return z.IsZero()
+		}
+	}
+
+	return v.IsZero()
+}
@@ -0,0 +1,78 @@
+package refl_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+func TestNoEmptyFields_recursive(t *testing.T) {
+	type DB struct {
+		DSN      string
+		MaxConns int `refl:"optional"`
+		secret   string
+	}
+
+	type Deps struct {
+		DB       DB
+		Replica  *DB
+		Cache    *DB `refl:"optional"`
+		Started  time.Time
+		Internal *DB `refl:"shallow"`
+		Skipped  int `refl:"-"`
+	}
+
+	d := Deps{
+		DB:       DB{DSN: "main"},
+		Replica:  &DB{},
+		Started:  time.Now(),
+		Internal: &DB{},
+	}
+
+	require.NoError(t, refl.NoEmptyFields(d))
+
+	err := refl.NoEmptyFields(&d, refl.NoEmptyFieldsRecursive)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, refl.ErrEmptyFields))
+	assert.EqualError(t, err, "empty fields: [Replica.DSN]")
+
+	var efe refl.EmptyFieldsError
+
+	require.True(t, errors.As(err, &efe))
+	assert.Equal(t, []string{"Replica.DSN"}, efe.Fields)
+
+	d.Started = time.Time{}
+	d.Replica = nil
+	d.DB.DSN = ""
+
+	assert.EqualError(t, refl.NoEmptyFields(&d, refl.NoEmptyFieldsRecursive),
+		"empty fields: [DB Replica Started]")
+
+	assert.EqualError(t, refl.NoEmptyFields(DB{}), "empty fields: [DSN secret]")
+	assert.EqualError(t, refl.NoEmptyFields(DB{}, func(o *refl.NoEmptyFieldsOptions) {
+		o.TagName = "custom"
+	}), "empty fields: [DSN MaxConns secret]")
+}
+
+func TestNoEmptyFields_cyclic(t *testing.T) {
+	type Node struct {
+		Name string
+		Next *Node
+	}
+
+	n := &Node{Name: "a"}
+	n.Next = n
+
+	assert.NoError(t, refl.NoEmptyFields(n, refl.NoEmptyFieldsRecursive))
+
+	list := Node{Name: "root", Next: &Node{Next: &Node{}}}
+	list.Next.Next.Next = list.Next
+
+	assert.EqualError(t, refl.NoEmptyFields(list, refl.NoEmptyFieldsRecursive),
+		"empty fields: [Next.Name Next.Next.Name]")
+	assert.EqualError(t, refl.NoEmptyFields(nil), "struct expected, nil received")
+}
@@ -0,0 +1,322 @@
+package refl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// RedactMode defines how sensitive value is redacted.
+type RedactMode string
+
+// Redact modes.
+const (
+	// RedactMask replaces strings with a mask, other scalars are zeroed.
+	RedactMask = RedactMode("mask")
+
+	// RedactZero replaces value with zero value.
+	RedactZero = RedactMode("zero")
+
+	// RedactHash replaces strings with SHA-256 hex hash, other scalars are zeroed.
+	RedactHash = RedactMode("hash")
+)
+
+// RedactOptions controls advanced behavior of Redact.
+type RedactOptions struct {
+	// TagName is a name of field tag that marks sensitive fields, default "sensitive".
+	//
+	// Tag value "true" enables default Mode, "mask", "zero" and "hash" enable a specific mode,
+	// "false" marks field as not sensitive even if its name matches NamePatterns.
+	TagName string
+
+	// NamePatterns are case-insensitive substrings of field names and string map keys
+	// that make values sensitive, default "password", "secret", "token".
+	NamePatterns []string
+
+	// Mode is applied to sensitive values without explicit mode, default RedactMask.
+	Mode RedactMode
+
+	// Mask replaces strings in RedactMask mode, default "***".
+	Mask string
+}
+
+// Redact returns a deep copy of a value with sensitive data redacted, the value is not modified.
+//
+// Structures, pointers, slices, arrays, maps and interfaces are traversed recursively.
+// Sensitive values are defined with field tag or with name patterns, see RedactOptions.
+//
+//	type Login struct {
+//		User     string
+//		Password string
+//		PIN      string `sensitive:"hash"`
+//		Token    string `sensitive:"false"`
+//	}
+func Redact(v interface{}, options ...func(o *RedactOptions)) interface{} {
+	if v == nil {
+		return nil
+	}
+
+	r := redactor{
+		visited: map[redactVisit]reflect.Value{},
+	}
+
+	for _, option := range options {
+		option(&r.opts)
+	}
+
+	if r.opts.TagName == "" {
+		r.opts.TagName = "sensitive"
+	}
+
+	if r.opts.NamePatterns == nil {
+		r.opts.NamePatterns = []string{"password", "secret", "token"}
+	}
+
+	if r.opts.Mode == "" {
+		r.opts.Mode = RedactMask
+	}
+
+	if r.opts.Mask == "" {
+		r.opts.Mask = "***"
+	}
+
+	patterns := make([]string, 0, len(r.opts.NamePatterns))
+	for _, p := range r.opts.NamePatterns {
+		patterns = append(patterns, strings.ToLower(p))
+	}
+
+	r.opts.NamePatterns = patterns
+
+	c := reflect.New(reflect.TypeOf(v)).Elem()
+	c.Set(reflect.ValueOf(Clone(v)))
+
+	r.walk(c)
+
+	return c.Interface()
+}
+
+type redactor struct {
+	opts RedactOptions
+
+	// visited maps pointers, slices and maps of cloned value to their redacted copies.
+	visited map[redactVisit]reflect.Value
+}
+
+type redactVisit struct {
+	t    reflect.Type
+	ptr  uintptr
+	len  int
+	mode RedactMode
+}
+
+// replace sets settable non-nil pointer, slice or map to a copy, so that it can be modified without
+// affecting source. It returns false if value is nil or was already copied in the same mode,
+// the copy is reused in that case to keep sharing and cyclic references.
+func (r *redactor) replace(v reflect.Value, mode RedactMode) bool {
+	if v.IsNil() {
+		return false
+	}
+
+	k := redactVisit{t: v.Type(), ptr: v.Pointer(), mode: mode}
+	if v.Kind() == reflect.Slice {
+		k.len = v.Len()
+	}
+
+	if c, ok := r.visited[k]; ok {
+		v.Set(c)
+
+		return false
+	}
+
+	var n reflect.Value
+
+	switch v.Kind() { //nolint:exhaustive // Only reference kinds are replaced.
+	case reflect.Ptr:
+		n = reflect.New(v.Type().Elem())
+		n.Elem().Set(v.Elem())
+	case reflect.Slice:
+		n = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(n, v)
+	case reflect.Map:
+		n = reflect.MakeMapWithSize(v.Type(), v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			n.SetMapIndex(iter.Key(), iter.Value())
+		}
+	}
+
+	r.visited[k] = n
+	v.Set(n)
+
+	return true
+}
+
+func (r *redactor) nameMode(name string) (RedactMode, bool) {
+	name = strings.ToLower(name)
+
+	for _, p := range r.opts.NamePatterns {
+		if strings.Contains(name, p) {
+			return r.opts.Mode, true
+		}
+	}
+
+	return "", false
+}
+
+func (r *redactor) fieldMode(sf reflect.StructField) (RedactMode, bool) {
+	switch tag := strings.Split(sf.Tag.Get(r.opts.TagName), ",")[0]; tag {
+	case "":
+		return r.nameMode(sf.Name)
+	case "false", "-":
+		return "", false
+	case "true":
+		return r.opts.Mode, true
+	default:
+		return RedactMode(tag), true
+	}
+}
+
+// walk redacts sensitive data in a settable value.
+//
+// Pointers, slices and maps are replaced with copies before modification, because Clone may keep
+// them shared with source, for example for fields with `clone:"shallow"` tag or values of hooks.
+// Copies are tracked, so cyclic values are supported.
+func (r *redactor) walk(v reflect.Value) {
+	switch v.Kind() { //nolint:exhaustive // Other kinds have no nested values.
+	case reflect.Ptr:
+		if r.replace(v, "") {
+			r.walk(v.Elem())
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+
+		n := reflect.New(v.Elem().Type()).Elem()
+		n.Set(v.Elem())
+		r.walk(n)
+		v.Set(n)
+	case reflect.Struct:
+		r.walkStruct(v)
+	case reflect.Slice:
+		if r.replace(v, "") {
+			r.walkItems(v)
+		}
+	case reflect.Array:
+		r.walkItems(v)
+	case reflect.Map:
+		if !r.replace(v, "") {
+			return
+		}
+
+		iter := v.MapRange()
+		for iter.Next() {
+			n := reflect.New(v.Type().Elem()).Elem()
+			n.Set(iter.Value())
+
+			if mode, ok := r.keyMode(iter.Key()); ok {
+				r.redact(n, mode)
+			} else {
+				r.walk(n)
+			}
+
+			v.SetMapIndex(iter.Key(), n)
+		}
+	}
+}
+
+func (r *redactor) walkItems(v reflect.Value) {
+	for i := 0; i < v.Len(); i++ {
+		r.walk(v.Index(i))
+	}
+}
+
+func (r *redactor) keyMode(k reflect.Value) (RedactMode, bool) {
+	if k.Kind() != reflect.String {
+		return "", false
+	}
+
+	return r.nameMode(k.String())
+}
+
+func (r *redactor) walkStruct(v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+
+		if sf.PkgPath != "" {
+			fv = reflect.NewAt(sf.Type, unsafe.Pointer(fv.UnsafeAddr())).Elem() //nolint:gosec // Access to unexported field.
+		}
+
+		if mode, ok := r.fieldMode(sf); ok {
+			r.redact(fv, mode)
+
+			continue
+		}
+
+		r.walk(fv)
+	}
+}
+
+// redact replaces sensitive settable value.
+func (r *redactor) redact(v reflect.Value, mode RedactMode) {
+	if mode == RedactZero {
+		v.Set(reflect.Zero(v.Type()))
+
+		return
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Other kinds are zeroed.
+	case reflect.String:
+		if v.Len() == 0 {
+			return
+		}
+
+		if mode == RedactHash {
+			h := sha256.Sum256([]byte(v.String()))
+			v.SetString(hex.EncodeToString(h[:]))
+		} else {
+			v.SetString(r.opts.Mask)
+		}
+	case reflect.Ptr:
+		if r.replace(v, mode) {
+			r.redact(v.Elem(), mode)
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+
+		n := reflect.New(v.Elem().Type()).Elem()
+		n.Set(v.Elem())
+		r.redact(n, mode)
+		v.Set(n)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && !r.replace(v, mode) {
+			return
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			r.redact(v.Index(i), mode)
+		}
+	case reflect.Map:
+		if !r.replace(v, mode) {
+			return
+		}
+
+		iter := v.MapRange()
+		for iter.Next() {
+			n := reflect.New(v.Type().Elem()).Elem()
+			n.Set(iter.Value())
+			r.redact(n, mode)
+			v.SetMapIndex(iter.Key(), n)
+		}
+	default:
+		v.Set(reflect.Zero(v.Type()))
+	}
+}
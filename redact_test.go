@@ -0,0 +1,126 @@
+package refl_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type redactCredentials struct {
+	User     string
+	Password string
+	PIN      int     `sensitive:"true"`
+	Key      string  `sensitive:"hash"`
+	Cookie   *string `sensitive:"zero"`
+	Token    string  `sensitive:"false"`
+	apiToken string
+}
+
+type redactRequest struct {
+	Credentials *redactCredentials
+	History     []redactCredentials
+	Headers     map[string][]string
+	Payload     interface{}
+}
+
+func TestRedact(t *testing.T) {
+	cookie := "session"
+	creds := &redactCredentials{
+		User:     "alice",
+		Password: "pass",
+		PIN:      1234,
+		Key:      "abc",
+		Cookie:   &cookie,
+		Token:    "visible",
+		apiToken: "hidden",
+	}
+
+	req := redactRequest{
+		Credentials: creds,
+		History:     []redactCredentials{{User: "bob", Password: "old"}},
+		Headers:     map[string][]string{"X-Auth-Token": {"t1", "t2"}, "Accept": {"json"}},
+		Payload:     map[string]interface{}{"client_secret": "s", "id": 1},
+	}
+
+	r, ok := refl.Redact(req).(redactRequest)
+	require.True(t, ok)
+
+	assert.Equal(t, &redactCredentials{
+		User:     "alice",
+		Password: "***",
+		Key:      "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		Token:    "visible",
+		apiToken: "***",
+	}, r.Credentials)
+	assert.Equal(t, "***", r.History[0].Password)
+	assert.Equal(t, map[string][]string{"X-Auth-Token": {"***", "***"}, "Accept": {"json"}}, r.Headers)
+	assert.Equal(t, map[string]interface{}{"client_secret": "***", "id": 1}, r.Payload)
+
+	// Source is not modified.
+	assert.Equal(t, "pass", creds.Password)
+	assert.Equal(t, "old", req.History[0].Password)
+	assert.Equal(t, []string{"t1", "t2"}, req.Headers["X-Auth-Token"])
+	assert.Equal(t, "s", req.Payload.(map[string]interface{})["client_secret"])
+
+	r, ok = refl.Redact(req, func(o *refl.RedactOptions) {
+		o.NamePatterns = []string{"User"}
+		o.Mode = refl.RedactZero
+	}).(redactRequest)
+	require.True(t, ok)
+
+	assert.Equal(t, "", r.Credentials.User)
+	assert.Equal(t, "pass", r.Credentials.Password)
+	assert.Equal(t, 0, r.Credentials.PIN)
+	assert.Nil(t, refl.Redact(nil))
+}
+
+func TestRedact_shared(t *testing.T) {
+	type holder struct {
+		Creds  *redactCredentials `clone:"shallow"`
+		Tokens []string           `clone:"shallow" sensitive:"true"`
+		Labels map[string]string  `clone:"shallow"`
+		Next   *holder
+	}
+
+	h := &holder{
+		Creds:  &redactCredentials{User: "alice", Password: "pass"},
+		Tokens: []string{"t1"},
+		Labels: map[string]string{"secret": "s"},
+	}
+	h.Next = h
+
+	r, ok := refl.Redact(h).(*holder)
+	require.True(t, ok)
+
+	assert.Equal(t, "***", r.Creds.Password)
+	assert.Equal(t, []string{"***"}, r.Tokens)
+	assert.Equal(t, map[string]string{"secret": "***"}, r.Labels)
+	assert.True(t, r.Next == r)
+
+	// Source is not modified.
+	assert.Equal(t, "pass", h.Creds.Password)
+	assert.Equal(t, []string{"t1"}, h.Tokens)
+	assert.Equal(t, map[string]string{"secret": "s"}, h.Labels)
+
+	m := map[string]interface{}{"password": "x"}
+	m["self"] = m
+
+	rm, ok := refl.Redact(m).(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "***", rm["password"])
+
+	self, ok := rm["self"].(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "***", self["password"])
+	assert.Equal(t, "x", m["password"])
+
+	s := []interface{}{"token", nil}
+	s[1] = s
+
+	rs, ok := refl.Redact(map[string]interface{}{"token": s}).(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "***", rs["token"].([]interface{})[0])
+	assert.Equal(t, "token", s[0])
+}
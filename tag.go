@@ -2,9 +2,7 @@ package refl
 
 import (
 	"errors"
-	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
 )
 
@@ -18,11 +16,18 @@ func (se SentinelError) Error() string {
 
 // Sentinel errors.
 const (
-	ErrNeedPointer          = SentinelError("could not find field value in struct")
-	ErrMissingFieldValue    = SentinelError("can not take address of structure, please pass a pointer")
+	ErrNeedPointer          = SentinelError("can not take address of structure, please pass a pointer")
+	ErrMissingFieldValue    = SentinelError("could not find field value in struct")
 	ErrMissingStructOrField = SentinelError("structPtr and fieldPtr are required")
 	ErrEmptyFields          = SentinelError("empty fields")
 	ErrStructExpected       = SentinelError("struct expected")
+	ErrNotImplemented       = SentinelError("does not implement")
+	ErrTypeMismatch         = SentinelError("type mismatch")
+	ErrValidationFailed     = SentinelError("validation failed")
+	ErrUnknownColumn        = SentinelError("unknown column")
+	ErrNotInteger           = SentinelError("not an integer")
+	ErrTypeNotFound         = SentinelError("type not found")
+	ErrPackageNotInModule   = SentinelError("package is not in module")
 )
 
 // HasTaggedFields checks if the structure has fields with tag name.
@@ -162,16 +167,24 @@ func WalkTaggedFields(v reflect.Value, f WalkTaggedFieldFn, tagName string) {
 	}
 }
 
+// hasTagOption checks if comma-separated tag value has an option after the name, e.g. "omitempty" in "name,omitempty".
+func hasTagOption(tag, option string) bool {
+	for _, o := range strings.Split(tag, ",")[1:] {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ReadBoolTag reads bool value from field tag into a value.
 func ReadBoolTag(tag reflect.StructTag, name string, holder *bool) error {
 	value, ok := tag.Lookup(name)
 	if ok {
-		v, err := strconv.ParseBool(value)
-		if err != nil {
-			return fmt.Errorf("failed to parse bool value %s in tag %s: %w", value, name, err)
+		if err := Convert(value, holder); err != nil {
+			return tagErr(name, value, reflect.TypeOf(holder).Elem(), err)
 		}
-
-		*holder = v
 	}
 
 	return nil
@@ -181,9 +194,10 @@ func ReadBoolTag(tag reflect.StructTag, name string, holder *bool) error {
 func ReadBoolPtrTag(tag reflect.StructTag, name string, holder **bool) error {
 	value, ok := tag.Lookup(name)
 	if ok {
-		v, err := strconv.ParseBool(value)
-		if err != nil {
-			return fmt.Errorf("failed to parse bool value %s in tag %s: %w", value, name, err)
+		var v bool
+
+		if err := Convert(value, &v); err != nil {
+			return tagErr(name, value, reflect.TypeOf(v), err)
 		}
 
 		*holder = &v
@@ -228,12 +242,9 @@ func ReadStringPtrTag(tag reflect.StructTag, name string, holder **string) {
 func ReadIntTag(tag reflect.StructTag, name string, holder *int64) error {
 	value, ok := tag.Lookup(name)
 	if ok {
-		v, err := strconv.ParseInt(value, 10, 64)
-		if err != nil {
-			return fmt.Errorf("failed to parse int value %s in tag %s: %w", value, name, err)
+		if err := Convert(value, holder); err != nil {
+			return tagErr(name, value, reflect.TypeOf(holder).Elem(), err)
 		}
-
-		*holder = v
 	}
 
 	return nil
@@ -243,9 +254,10 @@ func ReadIntTag(tag reflect.StructTag, name string, holder *int64) error {
 func ReadIntPtrTag(tag reflect.StructTag, name string, holder **int64) error {
 	value, ok := tag.Lookup(name)
 	if ok {
-		v, err := strconv.ParseInt(value, 10, 64)
-		if err != nil {
-			return fmt.Errorf("failed to parse int value %s in tag %s: %w", value, name, err)
+		var v int64
+
+		if err := Convert(value, &v); err != nil {
+			return tagErr(name, value, reflect.TypeOf(v), err)
 		}
 
 		*holder = &v
@@ -258,12 +270,9 @@ func ReadIntPtrTag(tag reflect.StructTag, name string, holder **int64) error {
 func ReadFloatTag(tag reflect.StructTag, name string, holder *float64) error {
 	value, ok := tag.Lookup(name)
 	if ok {
-		v, err := strconv.ParseFloat(value, 64)
-		if err != nil {
-			return fmt.Errorf("failed to parse float value %s in tag %s: %w", value, name, err)
+		if err := Convert(value, holder); err != nil {
+			return tagErr(name, value, reflect.TypeOf(holder).Elem(), err)
 		}
-
-		*holder = v
 	}
 
 	return nil
@@ -273,9 +282,10 @@ func ReadFloatTag(tag reflect.StructTag, name string, holder *float64) error {
 func ReadFloatPtrTag(tag reflect.StructTag, name string, holder **float64) error {
 	value, ok := tag.Lookup(name)
 	if ok {
-		v, err := strconv.ParseFloat(value, 64)
-		if err != nil {
-			return fmt.Errorf("failed to parse float value %s in tag %s: %w", value, name, err)
+		var v float64
+
+		if err := Convert(value, &v); err != nil {
+			return tagErr(name, value, reflect.TypeOf(v), err)
 		}
 
 		*holder = &v
@@ -284,21 +294,31 @@ func ReadFloatPtrTag(tag reflect.StructTag, name string, holder **float64) error
 	return nil
 }
 
-// JoinErrors joins non-nil errors.
+// JoinErrors joins non-nil errors into MultiError.
+//
+// Nested MultiError values are flattened, nil is returned if there are no errors.
 func JoinErrors(errs ...error) error {
-	join := ""
+	var res MultiError
 
 	for _, err := range errs {
-		if err != nil {
-			join += ", " + err.Error()
+		if err == nil {
+			continue
+		}
+
+		if me, ok := err.(MultiError); ok { //nolint:errorlint // Only direct MultiError is flattened.
+			res = append(res, me...)
+
+			continue
 		}
+
+		res = append(res, err)
 	}
 
-	if join != "" {
-		return errors.New(join[2:]) //nolint:err113
+	if len(res) == 0 {
+		return nil
 	}
 
-	return nil
+	return res
 }
 
 // FieldsFromTagsOptions controls advanced behavior of PopulateFieldsFromTags.
@@ -312,6 +332,8 @@ type FieldsFromTagsOptions struct {
 }
 
 // PopulateFieldsFromTags extracts values from field tag and puts them in according property of structPtr.
+//
+// Failed values are reported as FieldError with a name of structPtr property as Path.
 func PopulateFieldsFromTags(structPtr interface{}, fieldTag reflect.StructTag, options ...func(o *FieldsFromTagsOptions)) error {
 	pv := reflect.ValueOf(structPtr).Elem()
 	pt := pv.Type()
@@ -356,6 +378,12 @@ func PopulateFieldsFromTags(structPtr interface{}, fieldTag reflect.StructTag, o
 		}
 
 		if err != nil {
+			var fe FieldError
+			if errors.As(err, &fe) {
+				fe.Path = ptf.Name
+				err = fe
+			}
+
 			errs = append(errs, err)
 		}
 	}
@@ -412,3 +440,106 @@ func Tagged(structPtr, fieldPtr interface{}, tagName string) string {
 
 	return name
 }
+
+// FindTaggedPath returns tagged path of a nested entity field.
+//
+// Unlike FindTaggedName, the field can be reachable through nested structures and non-nil pointers.
+// Tagged path joins tag names with dots, untagged embedded structures are flattened.
+// Field path names Go fields, see FieldPath.
+//
+//	entity := MyEntity{}
+//	name, path, err := sm.FindTaggedPath(&entity, &entity.Sub.SubInt, "json")
+//	// name: "sub.sample_int", path: "Sub.SubInt"
+func FindTaggedPath(structPtr, fieldPtr interface{}, tagName string) (string, FieldPath, error) {
+	if structPtr == nil || fieldPtr == nil {
+		return "", "", ErrMissingStructOrField
+	}
+
+	v := reflect.Indirect(reflect.ValueOf(structPtr))
+
+	if !v.CanAddr() {
+		return "", "", ErrNeedPointer
+	}
+
+	fv := reflect.ValueOf(fieldPtr)
+	if fv.Kind() != reflect.Ptr || fv.IsNil() {
+		return "", "", ErrMissingFieldValue
+	}
+
+	f := taggedPathFinder{
+		tagName: tagName,
+		addr:    fv.Pointer(),
+		typ:     fv.Type().Elem(),
+		visited: map[uintptr]bool{},
+	}
+
+	if name, path, ok := f.find(v, "", ""); ok {
+		return name, path, nil
+	}
+
+	return "", "", ErrMissingFieldValue
+}
+
+// TaggedPath will try to find tagged path and panic on error.
+func TaggedPath(structPtr, fieldPtr interface{}, tagName string) string {
+	name, _, err := FindTaggedPath(structPtr, fieldPtr, tagName)
+	if err != nil {
+		panic(err)
+	}
+
+	return name
+}
+
+type taggedPathFinder struct {
+	tagName string
+	addr    uintptr
+	typ     reflect.Type
+	visited map[uintptr]bool
+}
+
+func (f taggedPathFinder) find(v reflect.Value, name string, path FieldPath) (string, FieldPath, bool) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+
+		tag := strings.Split(sf.Tag.Get(f.tagName), ",")[0]
+		if tag == "-" || (!sf.Anonymous && tag == "") {
+			continue
+		}
+
+		fieldName := name
+		if !sf.Anonymous {
+			fieldName = tag
+			if name != "" {
+				fieldName = name + "." + tag
+			}
+		}
+
+		fieldPath := path.Field(sf.Name)
+
+		if !sf.Anonymous && fv.UnsafeAddr() == f.addr && sf.Type == f.typ {
+			return fieldName, fieldPath, true
+		}
+
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() || f.visited[fv.Pointer()] {
+				continue
+			}
+
+			f.visited[fv.Pointer()] = true
+			fv = fv.Elem()
+		}
+
+		if fv.Kind() != reflect.Struct {
+			continue
+		}
+
+		if n, p, ok := f.find(fv, fieldName, fieldPath); ok {
+			return n, p, true
+		}
+	}
+
+	return "", "", false
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/swaggest/refl"
+	"github.com/swaggest/refl/internal/sample"
 )
 
 type (
@@ -151,6 +152,43 @@ func TestFindTaggedName(t *testing.T) {
 	assert.Equal(t, "deeper", refl.Tagged(&si.Data, &si.Data.Deeper, "json"))
 }
 
+func TestFindTaggedPath(t *testing.T) {
+	si := structWithInline{}
+
+	name, path, err := refl.FindTaggedPath(&si, &si.Data.Deeper.A, "json")
+	require.NoError(t, err)
+	assert.Equal(t, "data.deeper.a", name)
+	assert.Equal(t, refl.FieldPath("Data.Deeper.embedded.A"), path)
+
+	assert.Equal(t, "data.deeper", refl.TaggedPath(&si, &si.Data.Deeper, "json"))
+	assert.Equal(t, "data", refl.TaggedPath(&si, &si.Data, "json"))
+
+	s := sample.TestSampleStruct{SubSlice: []sample.TestSubStruct{{}}}
+	assert.Equal(t, "sub.sample_int", refl.TaggedPath(&s, &s.Sub.SubInt, "json"))
+
+	_, _, err = refl.FindTaggedPath(&s, &s.SubSlice[0].SubInt, "json")
+	assert.Equal(t, refl.ErrMissingFieldValue, err)
+
+	type node struct {
+		Next  *node `json:"next"`
+		Value int   `json:"value"`
+	}
+
+	n := node{Next: &node{Next: &node{}}}
+	n.Next.Next.Next = &n
+
+	name, path, err = refl.FindTaggedPath(&n, &n.Next.Next.Value, "json")
+	require.NoError(t, err)
+	assert.Equal(t, "next.next.value", name)
+	assert.Equal(t, refl.FieldPath("Next.Next.Value"), path)
+
+	_, _, err = refl.FindTaggedPath(&si, &si.Data.Deeper.B, "json")
+	assert.Equal(t, refl.ErrMissingFieldValue, err)
+
+	_, _, err = refl.FindTaggedPath(si, &si.Data, "json")
+	assert.Equal(t, refl.ErrNeedPointer, err)
+}
+
 func BenchmarkFindTaggedName(b *testing.B) {
 	se := structWithEmbedded{}
 	si := structWithInline{}
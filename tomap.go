@@ -0,0 +1,344 @@
+package refl
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	textMarshaler   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonMarshaler   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// ToMap converts structure (or a pointer to it) into a map tree keyed by tag names.
+//
+// Fields are iterated with WalkTaggedFields, so untagged fields and fields tagged with "-" are skipped,
+// embedded structures are flattened. Zero values of fields tagged with "omitempty" option are skipped.
+//
+// Nested structures become map[string]interface{}, slices and arrays become []interface{},
+// maps become map[string]interface{}. Structures that implement encoding.TextMarshaler or
+// json.Marshaler (for example time.Time) are kept as is.
+func ToMap(v interface{}, tagName string) map[string]interface{} {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := toMapper{tagName: tagName, visiting: map[uintptr]bool{}}
+
+	return t.structToMap(rv)
+}
+
+type toMapper struct {
+	tagName  string
+	visiting map[uintptr]bool
+}
+
+func (t *toMapper) structToMap(v reflect.Value) map[string]interface{} {
+	res := make(map[string]interface{}, v.NumField())
+
+	WalkTaggedFields(v, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		if !fv.CanInterface() {
+			return
+		}
+
+		if hasTagOption(sf.Tag.Get(t.tagName), "omitempty") && isZero(fv) {
+			return
+		}
+
+		res[tag] = t.value(fv)
+	}, t.tagName)
+
+	return res
+}
+
+func (t *toMapper) value(v reflect.Value) interface{} {
+	switch v.Kind() { //nolint:exhaustive // Other kinds are returned as is.
+	case reflect.Ptr:
+		if v.IsNil() || t.visiting[v.Pointer()] {
+			return nil
+		}
+
+		t.visiting[v.Pointer()] = true
+		defer delete(t.visiting, v.Pointer())
+
+		return t.value(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return t.value(v.Elem())
+	case reflect.Struct:
+		if isMarshaler(v.Type()) {
+			return v.Interface()
+		}
+
+		return t.structToMap(v)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8) {
+			return v.Interface()
+		}
+
+		res := make([]interface{}, v.Len())
+		for i := range res {
+			res[i] = t.value(v.Index(i))
+		}
+
+		return res
+	case reflect.Map:
+		if v.IsNil() {
+			return v.Interface()
+		}
+
+		res := make(map[string]interface{}, v.Len())
+
+		iter := v.MapRange()
+		for iter.Next() {
+			res[mapKeyString(iter.Key())] = t.value(iter.Value())
+		}
+
+		return res
+	}
+
+	return v.Interface()
+}
+
+func isMarshaler(t reflect.Type) bool {
+	pt := reflect.PtrTo(t)
+
+	return t.Implements(textMarshaler) || t.Implements(jsonMarshaler) ||
+		pt.Implements(textMarshaler) || pt.Implements(jsonMarshaler)
+}
+
+func mapKeyString(k reflect.Value) string {
+	if k.Kind() == reflect.String {
+		return k.String()
+	}
+
+	if !k.CanInterface() {
+		return fmt.Sprint(k)
+	}
+
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if b, err := tm.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+
+	return fmt.Sprint(k.Interface())
+}
+
+// FromMapOptions controls advanced behavior of FromMap.
+type FromMapOptions struct {
+	ConvertOptions
+
+	// AliasTag is a name of field tag with comma-separated alternative keys, default "alias".
+	AliasTag string
+}
+
+// FromMap decodes a map tree keyed by tag names into a structure pointer.
+//
+// It is a counterpart of ToMap. Values are converted with weak typing as in Convert, for example
+// numbers can be decoded from strings and json.Number.
+// Keys from `alias` tag are checked if main tag name is missing in the map.
+//
+// Failed values are reported as FieldError with path, for example "sub_slice[1].sample_int".
+func FromMap(m map[string]interface{}, structPtr interface{}, tagName string, options ...func(o *FromMapOptions)) error {
+	v := reflect.ValueOf(structPtr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrNeedPointer
+	}
+
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("%w, %s received", ErrStructExpected, v.Type().String())
+	}
+
+	f := fromMapper{tagName: tagName}
+	for _, option := range options {
+		option(&f.opts)
+	}
+
+	if f.opts.AliasTag == "" {
+		f.opts.AliasTag = "alias"
+	}
+
+	f.conv = newConverter(f.opts.ConvertOptions)
+
+	return f.decodeStruct(v, reflect.ValueOf(m), "")
+}
+
+type fromMapper struct {
+	tagName string
+	opts    FromMapOptions
+	conv    converter
+}
+
+func (f *fromMapper) decodeStruct(dst, src reflect.Value, path string) error {
+	if src.Kind() != reflect.Map || src.Type().Key().Kind() != reflect.String {
+		return fieldErr(path, f.tagName, src, dst.Type(), fmt.Errorf("%w: can not decode %s into %s",
+			ErrTypeMismatch, src.Type().String(), dst.Type().String()))
+	}
+
+	allocEmbedded(dst)
+
+	var errs []error
+
+	WalkTaggedFields(dst, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		if !fv.CanSet() {
+			return
+		}
+
+		keys := []string{tag}
+		if alias := sf.Tag.Get(f.opts.AliasTag); alias != "" {
+			keys = append(keys, strings.Split(alias, ",")...)
+		}
+
+		for _, key := range keys {
+			mv := src.MapIndex(reflect.ValueOf(key).Convert(src.Type().Key()))
+			if !mv.IsValid() {
+				continue
+			}
+
+			fieldPath := tag
+			if path != "" {
+				fieldPath = path + "." + tag
+			}
+
+			if err := f.decode(fv, mv, fieldPath); err != nil {
+				errs = append(errs, err)
+			}
+
+			break
+		}
+	}, f.tagName)
+
+	return JoinErrors(errs...)
+}
+
+func (f *fromMapper) decode(dst, src reflect.Value, path string) error {
+	for src.Kind() == reflect.Interface && !src.IsNil() {
+		src = src.Elem()
+	}
+
+	if !src.IsValid() || (src.Kind() == reflect.Interface || src.Kind() == reflect.Ptr) && src.IsNil() {
+		dst.Set(reflect.Zero(dst.Type()))
+
+		return nil
+	}
+
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+
+		return nil
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+
+		return f.decode(dst.Elem(), src, path)
+	}
+
+	switch {
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.Map:
+		return f.decodeStruct(dst, src, path)
+	case (dst.Kind() == reflect.Slice || dst.Kind() == reflect.Array) &&
+		(src.Kind() == reflect.Slice || src.Kind() == reflect.Array) && !isBytes(dst.Type()):
+		return f.decodeSlice(dst, src, path)
+	case dst.Kind() == reflect.Map && src.Kind() == reflect.Map:
+		return f.decodeMap(dst, src, path)
+	default:
+		return fieldErr(path, f.tagName, src, dst.Type(), f.conv.convert(dst, src))
+	}
+}
+
+func (f *fromMapper) decodeSlice(dst, src reflect.Value, path string) error {
+	if dst.Kind() == reflect.Slice {
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+	} else if dst.Len() < src.Len() {
+		return fieldErr(path, f.tagName, src, dst.Type(), fmt.Errorf("%w: %d items received for %s",
+			ErrTypeMismatch, src.Len(), dst.Type().String()))
+	}
+
+	var errs []error
+
+	for i := 0; i < src.Len(); i++ {
+		if err := f.decode(dst.Index(i), src.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return JoinErrors(errs...)
+}
+
+func (f *fromMapper) decodeMap(dst, src reflect.Value, path string) error {
+	t := dst.Type()
+	dst.Set(reflect.MakeMapWithSize(t, src.Len()))
+
+	var errs []error
+
+	iter := src.MapRange()
+	for iter.Next() {
+		keyPath := path + "." + mapKeyString(iter.Key())
+
+		key := reflect.New(t.Key()).Elem()
+		if err := f.decode(key, iter.Key(), keyPath); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		val := reflect.New(t.Elem()).Elem()
+		if err := f.decode(val, iter.Value(), keyPath); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		dst.SetMapIndex(key, val)
+	}
+
+	return JoinErrors(errs...)
+}
+
+// allocEmbedded initializes nil pointers of exported embedded structures recursively.
+func allocEmbedded(v reflect.Value) {
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.Anonymous {
+			continue
+		}
+
+		fv := v.Field(i)
+
+		if fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				if !fv.CanSet() {
+					continue
+				}
+
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+
+			fv = fv.Elem()
+		}
+
+		if fv.Kind() == reflect.Struct {
+			allocEmbedded(fv)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package refl_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+	"github.com/swaggest/refl/internal/sample"
+)
+
+type MapEmbedded struct {
+	Kind string `json:"kind"`
+}
+
+type mapEntity struct {
+	*MapEmbedded
+	ID       int64          `json:"id"`
+	Name     string         `json:"name,omitempty"`
+	Ratio    float32        `json:"ratio" alias:"rate,r"`
+	Enabled  *bool          `json:"enabled"`
+	Labels   map[string]int `json:"labels"`
+	Codes    map[int]string `json:"codes,omitempty"`
+	Created  time.Time      `json:"created"`
+	Tags     []string       `json:"tags"`
+	Skipped  string         `json:"-"`
+	Untagged string
+	Sample   sample.TestSampleStruct `json:"sample"`
+}
+
+func TestToMap(t *testing.T) {
+	created := time.Unix(1000, 0).UTC()
+	e := mapEntity{
+		MapEmbedded: &MapEmbedded{Kind: "k"},
+		ID:          1,
+		Labels:      map[string]int{"a": 1},
+		Created:     created,
+		Tags:        []string{"x"},
+		Skipped:     "skipped",
+		Untagged:    "untagged",
+		Sample: sample.TestSampleStruct{
+			Sub:      sample.TestSubStruct{SubInt: 5},
+			SubSlice: []sample.TestSubStruct{{SubInt: 7}},
+		},
+	}
+
+	m := refl.ToMap(&e, "json")
+
+	assert.Equal(t, map[string]interface{}{
+		"kind":    "k",
+		"id":      int64(1),
+		"ratio":   float32(0),
+		"enabled": nil,
+		"labels":  map[string]interface{}{"a": 1},
+		"created": created,
+		"tags":    []interface{}{"x"},
+		"sample": map[string]interface{}{
+			"simple_float64": 0.0,
+			"simple_bool":    false,
+			"sub":            map[string]interface{}{"sample_int": 5},
+			"sub_slice":      []interface{}{map[string]interface{}{"sample_int": 7}},
+			"anon_type_struct": map[string]interface{}{
+				"int": 0,
+			},
+		},
+	}, m)
+
+	assert.Nil(t, refl.ToMap(123, "json"))
+}
+
+func TestFromMap(t *testing.T) {
+	var e mapEntity
+
+	require.NoError(t, refl.FromMap(map[string]interface{}{
+		"kind":    "k",
+		"id":      "123",
+		"rate":    json.Number("1.5"),
+		"enabled": "true",
+		"labels":  map[string]interface{}{"a": 1.0, "b": "2"},
+		"codes":   map[string]interface{}{"200": "OK"},
+		"created": "2020-01-02T03:04:05Z",
+		"tags":    []interface{}{"x", 1},
+		"-":       "skipped",
+		"sample": map[string]interface{}{
+			"sub":       map[string]interface{}{"sample_int": "5"},
+			"sub_slice": []interface{}{map[string]interface{}{"sample_int": 7.0}},
+		},
+	}, &e, "json"))
+
+	assert.Equal(t, "k", e.Kind)
+	assert.Equal(t, int64(123), e.ID)
+	assert.Equal(t, float32(1.5), e.Ratio)
+	assert.True(t, *e.Enabled)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, e.Labels)
+	assert.Equal(t, map[int]string{200: "OK"}, e.Codes)
+	assert.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), e.Created)
+	assert.Equal(t, []string{"x", "1"}, e.Tags)
+	assert.Empty(t, e.Skipped)
+	assert.Equal(t, 5, e.Sample.Sub.SubInt)
+	assert.Equal(t, 7, e.Sample.SubSlice[0].SubInt)
+
+	m := refl.ToMap(e, "json")
+	e2 := mapEntity{}
+
+	require.NoError(t, refl.FromMap(m, &e2, "json"))
+	assert.Equal(t, e, e2)
+}
+
+func TestFromMap_errors(t *testing.T) {
+	var e mapEntity
+
+	err := refl.FromMap(map[string]interface{}{
+		"id":    "abc",
+		"ratio": 1e100,
+		"sample": map[string]interface{}{
+			"sub_slice": []interface{}{
+				map[string]interface{}{"sample_int": 1},
+				map[string]interface{}{"sample_int": 1.5},
+			},
+			"sub": 123,
+		},
+	}, &e, "json")
+
+	assert.EqualError(t, err, `id: strconv.ParseInt: parsing "abc": invalid syntax, `+
+		`ratio: value out of range: value 1e+100 overflows float32, `+
+		`sample.sub: type mismatch: can not convert int to sample.TestSubStruct, `+
+		`sample.sub_slice[1].sample_int: not an integer: value 1.5 has fractional part and can not be converted to int`)
+
+	assert.True(t, errors.Is(refl.FromMap(nil, e, "json"), refl.ErrNeedPointer))
+	assert.True(t, errors.Is(refl.FromMap(nil, new(int), "json"), refl.ErrStructExpected))
+}
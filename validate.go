@@ -0,0 +1,476 @@
+package refl
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// ValidationError describes a failed validation rule.
+type ValidationError struct {
+	// Path is a location of the value with tag names, for example "items[0].name".
+	Path string
+
+	// Rule is a name of the failed rule, for example "minLength".
+	Rule string
+
+	// Param is a value of rule tag.
+	Param string
+
+	// Value is the checked value.
+	Value interface{}
+
+	// Message describes the failure.
+	Message string
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors is a list of failed validation rules.
+//
+// It matches ErrValidationFailed with errors.Is, individual ValidationError items are
+// available to errors.Is and errors.As, same as in MultiError.
+type ValidationErrors []ValidationError
+
+// Error implements error.
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(ve))
+	for _, e := range ve {
+		msgs = append(msgs, e.Error())
+	}
+
+	return strings.Join(msgs, ", ")
+}
+
+// Unwrap returns ErrValidationFailed and failed rules.
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(ve)+1)
+	errs = append(errs, ErrValidationFailed)
+
+	for _, e := range ve {
+		errs = append(errs, e)
+	}
+
+	return errs
+}
+
+// Is checks if target is ErrValidationFailed or matches any of failed rules.
+func (ve ValidationErrors) Is(target error) bool {
+	return MultiError(ve.Unwrap()).Is(target)
+}
+
+// As finds the first failed rule that matches target.
+func (ve ValidationErrors) As(target interface{}) bool {
+	return MultiError(ve.Unwrap()).As(target)
+}
+
+// ValidationRule checks value against a parameter from field tag.
+//
+// Value is dereferenced, rule is not called for nil pointers.
+type ValidationRule func(v reflect.Value, param string) error
+
+// Validator checks structures against rules defined in field tags.
+//
+// Supported tags:
+//   - `required:"true"` value must not be zero,
+//   - `min:"1.5"` and `max:"10"` bound numbers,
+//   - `minLength:"1"` and `maxLength:"10"` bound length of strings (in runes), slices and maps,
+//   - `pattern:"^[a-z]+$"` is a regular expression for strings,
+//   - `enum:"a,b,c"` is a comma-separated list of allowed values,
+//   - `multipleOf:"0.5"` restricts numbers to multiples.
+//
+// Nested structures, pointers, slices, arrays and maps are checked recursively.
+// Rules are parsed once per type and cached.
+//
+// Zero value is ready to use.
+type Validator struct {
+	// NameTag is a name of tag that defines field names in error paths, default "json".
+	// Go field name is used if tag is missing.
+	NameTag string
+
+	mu    sync.Mutex
+	rules map[string]ValidationRule
+	cache sync.Map
+}
+
+var defaultValidator = &Validator{}
+
+// Validate checks structure (or a pointer to it) against rules defined in field tags.
+//
+// Returned error is ValidationErrors if rules have failed.
+func Validate(v interface{}) error {
+	return defaultValidator.Validate(v)
+}
+
+// AddRule registers custom validation rule, it is applied to fields that have a tag with rule name.
+//
+//	v.AddRule("uuid", func(v reflect.Value, param string) error { ... })
+//	...
+//	ID string `uuid:"true"`
+func (vl *Validator) AddRule(name string, rule ValidationRule) {
+	vl.mu.Lock()
+	defer vl.mu.Unlock()
+
+	if vl.rules == nil {
+		vl.rules = map[string]ValidationRule{}
+	}
+
+	vl.rules[name] = rule
+
+	vl.cache.Range(func(key, _ interface{}) bool {
+		vl.cache.Delete(key)
+
+		return true
+	})
+}
+
+// Validate checks structure (or a pointer to it) against rules defined in field tags.
+//
+// Returned error is ValidationErrors if rules have failed.
+func (vl *Validator) Validate(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		received := "nil"
+		if rv.IsValid() {
+			received = rv.Type().String()
+		}
+
+		return fmt.Errorf("%w, %s received", ErrStructExpected, received)
+	}
+
+	w := validationWalker{vl: vl, visiting: map[uintptr]bool{}}
+	if err := w.walk(rv, ""); err != nil {
+		return err
+	}
+
+	if len(w.errs) > 0 {
+		return w.errs
+	}
+
+	return nil
+}
+
+type fieldRules struct {
+	Required   bool
+	Min        *float64
+	Max        *float64
+	MinLength  *int64
+	MaxLength  *int64
+	Pattern    *string
+	Enum       *string
+	MultipleOf *float64
+}
+
+type fieldValidation struct {
+	index   int
+	name    string
+	flatten bool
+	tag     reflect.StructTag
+	rules   fieldRules
+	pattern *regexp.Regexp
+	enum    []string
+	custom  map[string]ValidationRule
+}
+
+type typeValidation struct {
+	fields []fieldValidation
+	err    error
+}
+
+func (vl *Validator) typeValidation(t reflect.Type) *typeValidation {
+	if tv, ok := vl.cache.Load(t); ok {
+		return tv.(*typeValidation) //nolint:forcetypeassert // Cache only has *typeValidation.
+	}
+
+	nameTag := vl.NameTag
+	if nameTag == "" {
+		nameTag = "json"
+	}
+
+	vl.mu.Lock()
+	custom := make(map[string]ValidationRule, len(vl.rules))
+
+	for name, rule := range vl.rules {
+		custom[name] = rule
+	}
+	vl.mu.Unlock()
+
+	tv := &typeValidation{}
+
+	var errs []error
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+
+		fv := fieldValidation{index: i, name: sf.Name, tag: sf.Tag}
+
+		if name := strings.Split(sf.Tag.Get(nameTag), ",")[0]; name != "" && name != "-" {
+			fv.name = name
+		} else if sf.Anonymous {
+			fv.flatten = true
+		}
+
+		path := t.String() + "." + sf.Name
+
+		if err := PopulateFieldsFromTags(&fv.rules, sf.Tag); err != nil {
+			for _, e := range err.(MultiError) { //nolint:errorlint,forcetypeassert // JoinErrors result.
+				errs = append(errs, ruleErr(path, e))
+			}
+		}
+
+		if fv.rules.Pattern != nil {
+			re, err := regexp.Compile(*fv.rules.Pattern)
+			if err != nil {
+				errs = append(errs, FieldError{Path: path, Tag: "pattern", Value: *fv.rules.Pattern, Type: regexpType, Err: err})
+			}
+
+			fv.pattern = re
+		}
+
+		if fv.rules.Enum != nil {
+			fv.enum = strings.Split(*fv.rules.Enum, ",")
+		}
+
+		for name, rule := range custom {
+			if _, ok := sf.Tag.Lookup(name); ok {
+				if fv.custom == nil {
+					fv.custom = map[string]ValidationRule{}
+				}
+
+				fv.custom[name] = rule
+			}
+		}
+
+		tv.fields = append(tv.fields, fv)
+	}
+
+	tv.err = JoinErrors(errs...)
+
+	vl.cache.Store(t, tv)
+
+	return tv
+}
+
+var regexpType = reflect.TypeOf((*regexp.Regexp)(nil))
+
+// ruleErr returns FieldError of a rule tag with a type-qualified field path.
+func ruleErr(path string, err error) error {
+	fe := FieldError{Path: path, Err: err}
+
+	var tfe FieldError
+	if errors.As(err, &tfe) {
+		fe.Tag = tfe.Tag
+		fe.Value = tfe.Value
+		fe.Type = tfe.Type
+	}
+
+	return fe
+}
+
+type validationWalker struct {
+	vl *Validator
+
+	// visiting holds pointers that are being descended to stop on cyclic values,
+	// pointers shared by siblings are checked at each path.
+	visiting map[uintptr]bool
+	errs     ValidationErrors
+}
+
+// walk checks nested values, it returns error if rules of a type can not be parsed.
+func (w *validationWalker) walk(v reflect.Value, path FieldPath) error {
+	switch v.Kind() { //nolint:exhaustive // Other kinds have no nested values.
+	case reflect.Ptr:
+		if v.IsNil() || w.visiting[v.Pointer()] {
+			return nil
+		}
+
+		w.visiting[v.Pointer()] = true
+		defer delete(w.visiting, v.Pointer())
+
+		return w.walk(v.Elem(), path)
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return w.walk(v.Elem(), path)
+	case reflect.Struct:
+		return w.walkStruct(v, path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := w.walk(v.Index(i), path.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := w.walk(iter.Value(), path.Key(iter.Key())); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w *validationWalker) walkStruct(v reflect.Value, path FieldPath) error {
+	tv := w.vl.typeValidation(v.Type())
+	if tv.err != nil {
+		return tv.err
+	}
+
+	for _, f := range tv.fields {
+		fv := v.Field(f.index)
+
+		fieldPath := path
+		if !f.flatten {
+			fieldPath = path.Field(f.name)
+		}
+
+		w.check(fv, f, fieldPath)
+
+		if err := w.walk(fv, fieldPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *validationWalker) fail(path FieldPath, rule, param string, v reflect.Value, format string, args ...interface{}) {
+	e := ValidationError{
+		Path:    string(path),
+		Rule:    rule,
+		Param:   param,
+		Message: fmt.Sprintf(format, args...),
+	}
+
+	if v.CanInterface() {
+		e.Value = v.Interface()
+	}
+
+	w.errs = append(w.errs, e)
+}
+
+func (w *validationWalker) check(v reflect.Value, f fieldValidation, path FieldPath) {
+	if f.rules.Required && isZero(v) {
+		w.fail(path, "required", f.tag.Get("required"), v, "value is required")
+
+		return
+	}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+
+		v = v.Elem()
+	}
+
+	w.checkNumber(v, f, path)
+	w.checkLength(v, f, path)
+
+	if f.pattern != nil && v.Kind() == reflect.String && !f.pattern.MatchString(v.String()) {
+		w.fail(path, "pattern", f.pattern.String(), v, "value must match pattern %s", f.pattern.String())
+	}
+
+	if f.enum != nil {
+		s := fmt.Sprint(v)
+		found := false
+
+		for _, e := range f.enum {
+			if e == s {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			w.fail(path, "enum", *f.rules.Enum, v, "value must be one of [%s]", *f.rules.Enum)
+		}
+	}
+
+	for name, rule := range f.custom {
+		param := f.tag.Get(name)
+		if err := rule(v, param); err != nil {
+			w.fail(path, name, param, v, "%s", err.Error())
+		}
+	}
+}
+
+func numberValue(v reflect.Value) (float64, bool) {
+	switch v.Kind() { //nolint:exhaustive // Other kinds are not numbers.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+
+	return 0, false
+}
+
+func (w *validationWalker) checkNumber(v reflect.Value, f fieldValidation, path FieldPath) {
+	n, ok := numberValue(v)
+	if !ok {
+		return
+	}
+
+	if f.rules.Min != nil && n < *f.rules.Min {
+		w.fail(path, "min", f.tag.Get("min"), v, "value must be greater than or equal to %v", *f.rules.Min)
+	}
+
+	if f.rules.Max != nil && n > *f.rules.Max {
+		w.fail(path, "max", f.tag.Get("max"), v, "value must be less than or equal to %v", *f.rules.Max)
+	}
+
+	if m := f.rules.MultipleOf; m != nil && *m != 0 {
+		q := n / *m
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			w.fail(path, "multipleOf", f.tag.Get("multipleOf"), v, "value must be a multiple of %v", *m)
+		}
+	}
+}
+
+func (w *validationWalker) checkLength(v reflect.Value, f fieldValidation, path FieldPath) {
+	if f.rules.MinLength == nil && f.rules.MaxLength == nil {
+		return
+	}
+
+	var l int64
+
+	switch v.Kind() { //nolint:exhaustive // Other kinds have no length.
+	case reflect.String:
+		l = int64(utf8.RuneCountInString(v.String()))
+	case reflect.Slice, reflect.Array, reflect.Map:
+		l = int64(v.Len())
+	default:
+		return
+	}
+
+	if f.rules.MinLength != nil && l < *f.rules.MinLength {
+		w.fail(path, "minLength", f.tag.Get("minLength"), v, "length must be greater than or equal to %d", *f.rules.MinLength)
+	}
+
+	if f.rules.MaxLength != nil && l > *f.rules.MaxLength {
+		w.fail(path, "maxLength", f.tag.Get("maxLength"), v, "length must be less than or equal to %d", *f.rules.MaxLength)
+	}
+}
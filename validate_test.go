@@ -0,0 +1,178 @@
+package refl_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type validateItem struct {
+	Name  string  `json:"name" required:"true" minLength:"2" maxLength:"5"`
+	Price float64 `json:"price" min:"0" max:"100" multipleOf:"0.5"`
+}
+
+type validateBase struct {
+	ID string `json:"id" pattern:"^[a-z]+$"`
+}
+
+type validateOrder struct {
+	validateBase
+	Status string                  `json:"status" enum:"new,paid"`
+	Items  []validateItem          `json:"items" minLength:"1"`
+	Meta   map[string]validateItem `json:"meta"`
+	Note   *string                 `json:"note" maxLength:"3"`
+	Count  *int                    `json:"count" required:"true" min:"1"`
+	Owner  *validateItem
+}
+
+func TestValidate(t *testing.T) {
+	note := "long note"
+	count := 0
+
+	o := validateOrder{
+		validateBase: validateBase{ID: "A1"},
+		Status:       "void",
+		Items:        []validateItem{{Name: "a", Price: 0.7}, {Name: "abc", Price: 101}},
+		Meta:         map[string]validateItem{"x": {Price: -1}},
+		Note:         &note,
+		Count:        &count,
+		Owner:        &validateItem{Name: "too long"},
+	}
+
+	err := refl.Validate(o)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, refl.ErrValidationFailed))
+
+	var ve refl.ValidationErrors
+
+	require.True(t, errors.As(err, &ve))
+
+	var msgs []string
+	for _, e := range ve {
+		msgs = append(msgs, e.Rule+" "+e.Error())
+	}
+
+	assert.Equal(t, []string{
+		"pattern id: value must match pattern ^[a-z]+$",
+		"enum status: value must be one of [new,paid]",
+		"minLength items[0].name: length must be greater than or equal to 2",
+		"multipleOf items[0].price: value must be a multiple of 0.5",
+		"max items[1].price: value must be less than or equal to 100",
+		"required meta[x].name: value is required",
+		"min meta[x].price: value must be greater than or equal to 0",
+		"maxLength note: length must be less than or equal to 3",
+		"min count: value must be greater than or equal to 1",
+		"maxLength Owner.name: length must be less than or equal to 5",
+	}, msgs)
+
+	assert.Equal(t, "A1", ve[0].Value)
+	assert.Equal(t, "^[a-z]+$", ve[0].Param)
+
+	var first refl.ValidationError
+
+	require.True(t, errors.As(err, &first))
+	assert.Equal(t, ve[0], first)
+	assert.True(t, errors.Is(err, ve[1]))
+	assert.True(t, errors.Is(refl.JoinErrors(err, errors.New("other")), refl.ErrValidationFailed))
+
+	valid := validateOrder{
+		validateBase: validateBase{ID: "abc"},
+		Status:       "new",
+		Items:        []validateItem{{Name: "ab", Price: 1.5}},
+		Count:        &[]int{1}[0],
+	}
+
+	require.NoError(t, refl.Validate(&valid))
+
+	valid.Count = nil
+	assert.EqualError(t, refl.Validate(&valid), "count: value is required")
+
+	assert.True(t, errors.Is(refl.Validate(1), refl.ErrStructExpected))
+}
+
+func TestValidate_sharedPointers(t *testing.T) {
+	type node struct {
+		N    int   `json:"n" min:"1"`
+		A    *node `json:"a"`
+		B    *node `json:"b"`
+		Next *node `json:"next"`
+	}
+
+	shared := &node{N: 1}
+	root := node{N: 1, A: shared, B: shared}
+	shared.Next = &root
+	shared.N = 0
+
+	assert.EqualError(t, refl.Validate(&root), "a.n: value must be greater than or equal to 1, "+
+		"b.n: value must be greater than or equal to 1")
+}
+
+func TestValidator_AddRule(t *testing.T) {
+	type user struct {
+		Email string `query:"email" contains:"@"`
+	}
+
+	v := refl.Validator{NameTag: "query"}
+
+	require.NoError(t, v.Validate(user{Email: "foo"}))
+
+	v.AddRule("contains", func(v reflect.Value, param string) error {
+		if !strings.Contains(v.String(), param) {
+			return errors.New("value must contain " + param)
+		}
+
+		return nil
+	})
+
+	assert.EqualError(t, v.Validate(user{Email: "foo"}), "email: value must contain @")
+	assert.NoError(t, v.Validate(user{Email: "foo@bar"}))
+}
+
+func TestValidate_invalidRules(t *testing.T) {
+	type invalid struct {
+		Name string `pattern:"(" min:"abc"`
+	}
+
+	err := refl.Validate(invalid{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "refl_test.invalid.Name: failed to parse float value abc in tag min")
+	assert.Contains(t, err.Error(), "refl_test.invalid.Name: error parsing regexp")
+
+	me, ok := err.(refl.MultiError) //nolint:errorlint // Aggregated error.
+	require.True(t, ok)
+	require.Len(t, me, 2)
+
+	var fe refl.FieldError
+
+	require.True(t, errors.As(me[0], &fe))
+	assert.Equal(t, "refl_test.invalid.Name", fe.Path)
+	assert.Equal(t, "min", fe.Tag)
+	assert.Equal(t, "abc", fe.Value)
+
+	require.True(t, errors.As(me[1], &fe))
+	assert.Equal(t, "refl_test.invalid.Name", fe.Path)
+	assert.Equal(t, "pattern", fe.Tag)
+	assert.Equal(t, "(", fe.Value)
+}
+
+func BenchmarkValidate(b *testing.B) {
+	o := validateOrder{
+		validateBase: validateBase{ID: "abc"},
+		Status:       "new",
+		Items:        []validateItem{{Name: "ab", Price: 1.5}},
+		Count:        &[]int{1}[0],
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if err := refl.Validate(o); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package refl
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// ValuesOptions controls advanced behavior of DecodeValues and EncodeValues.
+type ValuesOptions struct {
+	ConvertOptions
+}
+
+func valuesKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "[" + name + "]"
+}
+
+// DecodeValues decodes url.Values (for example query or form parameters) into a structure pointer.
+//
+// Fields are iterated with WalkTaggedFields, embedded structures are flattened.
+// Repeated keys are decoded into slices, keys with "[]" suffix are also accepted for slices.
+// Nested structures and maps are decoded in deepObject style, for example "filter[name]=x".
+// Pointers are allocated only if a value is present.
+//
+// Values are converted as in Convert, failed values are reported as FieldError with the key as path.
+func DecodeValues(values url.Values, structPtr interface{}, tagName string, options ...func(o *ValuesOptions)) error {
+	v := reflect.ValueOf(structPtr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrNeedPointer
+	}
+
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("%w, %s received", ErrStructExpected, v.Type().String())
+	}
+
+	opts := ValuesOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	d := valuesDecoder{tagName: tagName, values: values, conv: newConverter(opts.ConvertOptions)}
+
+	return d.decodeStruct(v, "")
+}
+
+type valuesDecoder struct {
+	tagName string
+	values  url.Values
+	conv    converter
+}
+
+func (d valuesDecoder) decodeStruct(v reflect.Value, prefix string) error {
+	allocEmbedded(v)
+
+	var errs []error
+
+	WalkTaggedFields(v, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		if !fv.CanSet() {
+			return
+		}
+
+		if err := d.decode(fv, valuesKey(prefix, tag)); err != nil {
+			errs = append(errs, err)
+		}
+	}, d.tagName)
+
+	return JoinErrors(errs...)
+}
+
+// has checks if values have the key or nested keys.
+func (d valuesDecoder) has(key string) bool {
+	if _, ok := d.values[key]; ok {
+		return true
+	}
+
+	for k := range d.values {
+		if strings.HasPrefix(k, key+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d valuesDecoder) decode(v reflect.Value, key string) error {
+	t := v.Type()
+
+	if isFlatLeaf(t) {
+		vals := d.values[key]
+		if len(vals) == 0 {
+			return nil
+		}
+
+		src := reflect.ValueOf(vals[0])
+
+		return fieldErr(key, d.tagName, src, t, d.conv.convert(v, src))
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Leaves are handled above, other kinds are not supported.
+	case reflect.Ptr:
+		if !d.has(key) {
+			return nil
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.New(t.Elem()))
+		}
+
+		return d.decode(v.Elem(), key)
+	case reflect.Slice, reflect.Array:
+		if !isFlatLeaf(t.Elem()) {
+			return nil
+		}
+
+		vals, ok := d.values[key]
+		if !ok {
+			vals, ok = d.values[key+"[]"]
+		}
+
+		if !ok {
+			return nil
+		}
+
+		src := reflect.ValueOf(vals)
+
+		return fieldErr(key, d.tagName, src, t, d.conv.convert(v, src))
+	case reflect.Struct:
+		return d.decodeStruct(v, key)
+	case reflect.Map:
+		return d.decodeMap(v, key)
+	}
+
+	return nil
+}
+
+func (d valuesDecoder) decodeMap(v reflect.Value, key string) error {
+	var (
+		errs []error
+		t    = v.Type()
+		seen = map[string]bool{}
+	)
+
+	prefix := key + "["
+
+	for k := range d.values {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		mk := k[len(prefix):]
+
+		pos := strings.Index(mk, "]")
+		if pos == -1 {
+			continue
+		}
+
+		mk = mk[:pos]
+		if seen[mk] {
+			continue
+		}
+
+		seen[mk] = true
+		itemKey := valuesKey(key, mk)
+
+		kv := reflect.New(t.Key()).Elem()
+		if err := d.conv.convert(kv, reflect.ValueOf(mk)); err != nil {
+			errs = append(errs, fieldErr(itemKey, d.tagName, reflect.ValueOf(mk), t.Key(), err))
+
+			continue
+		}
+
+		val := reflect.New(t.Elem()).Elem()
+		if err := d.decode(val, itemKey); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(t))
+		}
+
+		v.SetMapIndex(kv, val)
+	}
+
+	return JoinErrors(errs...)
+}
+
+// EncodeValues encodes structure (or a pointer to it) into url.Values.
+//
+// It is a counterpart of DecodeValues. Slices are encoded as repeated keys, nested structures and
+// maps are encoded in deepObject style, nil pointers are skipped. Zero values of fields tagged with
+// "omitempty" option are skipped.
+func EncodeValues(v interface{}, tagName string, options ...func(o *ValuesOptions)) url.Values {
+	res := url.Values{}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return res
+	}
+
+	opts := ValuesOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	e := valuesEncoder{
+		tagName:  tagName,
+		conv:     newConverter(opts.ConvertOptions),
+		visiting: map[uintptr]bool{},
+		res:      res,
+	}
+
+	e.encodeStruct(rv, "")
+
+	return res
+}
+
+type valuesEncoder struct {
+	tagName  string
+	conv     converter
+	visiting map[uintptr]bool
+	res      url.Values
+}
+
+func (e valuesEncoder) encodeStruct(v reflect.Value, prefix string) {
+	WalkTaggedFields(v, func(fv reflect.Value, sf reflect.StructField, tag string) {
+		if !fv.CanInterface() {
+			return
+		}
+
+		if hasTagOption(sf.Tag.Get(e.tagName), "omitempty") && isZero(fv) {
+			return
+		}
+
+		e.encode(fv, valuesKey(prefix, tag))
+	}, e.tagName)
+}
+
+func (e valuesEncoder) encode(v reflect.Value, key string) {
+	switch v.Kind() { //nolint:exhaustive // Other kinds are handled below.
+	case reflect.Ptr:
+		if v.IsNil() || e.visiting[v.Pointer()] {
+			return
+		}
+
+		e.visiting[v.Pointer()] = true
+		defer delete(e.visiting, v.Pointer())
+
+		e.encode(v.Elem(), key)
+
+		return
+	case reflect.Interface:
+		if !v.IsNil() {
+			e.encode(v.Elem(), key)
+		}
+
+		return
+	}
+
+	if isFlatLeaf(v.Type()) {
+		var s string
+		if err := e.conv.convert(reflect.ValueOf(&s).Elem(), v); err == nil {
+			e.res.Add(key, s)
+		}
+
+		return
+	}
+
+	switch v.Kind() { //nolint:exhaustive // Leaves are handled above, other kinds are not supported.
+	case reflect.Slice, reflect.Array:
+		if !isFlatLeaf(v.Type().Elem()) {
+			return
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			e.encode(v.Index(i), key)
+		}
+	case reflect.Struct:
+		e.encodeStruct(v, key)
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			e.encode(iter.Value(), valuesKey(key, mapKeyString(iter.Key())))
+		}
+	}
+}
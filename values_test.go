@@ -0,0 +1,94 @@
+package refl_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggest/refl"
+)
+
+type valuesFilter struct {
+	Name  string `query:"name"`
+	Count *int   `query:"count"`
+}
+
+type valuesPage struct {
+	Limit int `query:"limit"`
+}
+
+type valuesQuery struct {
+	valuesPage
+	IDs     []int             `query:"id"`
+	Search  *string           `query:"search"`
+	Missing *string           `query:"missing"`
+	Since   time.Time         `query:"since"`
+	Filter  valuesFilter      `query:"filter"`
+	Opt     *valuesFilter     `query:"opt"`
+	Labels  map[string]string `query:"labels"`
+	Empty   string            `query:"empty,omitempty"`
+	Skipped string            `query:"-"`
+}
+
+func TestDecodeValues(t *testing.T) {
+	vals, err := url.ParseQuery("limit=10&id=1&id=2&search=foo&since=2021-02-03T00:00:00Z" +
+		"&filter[name]=x&filter[count]=3&labels[a]=b&labels[c]=d&Skipped=1")
+	require.NoError(t, err)
+
+	var q valuesQuery
+
+	require.NoError(t, refl.DecodeValues(vals, &q, "query"))
+
+	assert.Equal(t, 10, q.Limit)
+	assert.Equal(t, []int{1, 2}, q.IDs)
+	require.NotNil(t, q.Search)
+	assert.Equal(t, "foo", *q.Search)
+	assert.Nil(t, q.Missing)
+	assert.Nil(t, q.Opt)
+	assert.Equal(t, time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC), q.Since)
+	assert.Equal(t, "x", q.Filter.Name)
+	require.NotNil(t, q.Filter.Count)
+	assert.Equal(t, 3, *q.Filter.Count)
+	assert.Equal(t, map[string]string{"a": "b", "c": "d"}, q.Labels)
+	assert.Equal(t, "", q.Skipped)
+
+	vals.Del("id")
+	vals["id[]"] = []string{"3"}
+
+	require.NoError(t, refl.DecodeValues(vals, &q, "query"))
+	assert.Equal(t, []int{3}, q.IDs)
+
+	enc := refl.EncodeValues(q, "query")
+	assert.Equal(t, url.Values{
+		"limit":         {"10"},
+		"id":            {"3"},
+		"search":        {"foo"},
+		"since":         {"2021-02-03T00:00:00Z"},
+		"filter[name]":  {"x"},
+		"filter[count]": {"3"},
+		"labels[a]":     {"b"},
+		"labels[c]":     {"d"},
+	}, enc)
+
+	var q2 valuesQuery
+
+	require.NoError(t, refl.DecodeValues(enc, &q2, "query"))
+	assert.Equal(t, q, q2)
+}
+
+func TestDecodeValues_errors(t *testing.T) {
+	var q valuesQuery
+
+	err := refl.DecodeValues(url.Values{
+		"limit":         {"abc"},
+		"id":            {"1", "b"},
+		"filter[count]": {"c"},
+	}, &q, "query")
+	assert.EqualError(t, err, `limit: strconv.ParseInt: parsing "abc": invalid syntax, `+
+		`id: item 1: strconv.ParseInt: parsing "b": invalid syntax, `+
+		`filter[count]: strconv.ParseInt: parsing "c": invalid syntax`)
+
+	assert.Equal(t, refl.ErrNeedPointer, refl.DecodeValues(nil, q, "query"))
+}
@@ -0,0 +1,114 @@
+package refl
+
+import (
+	"reflect"
+)
+
+// TypeStep describes how a type node is reached from its parent.
+type TypeStep int
+
+// Type steps.
+const (
+	// TypeRoot is the walked type itself.
+	TypeRoot TypeStep = iota
+
+	// TypeField is a struct field, path segment is field name, for example "Sub.Name".
+	TypeField
+
+	// TypeElem is an element of slice, array or channel, path segment is "[]".
+	TypeElem
+
+	// TypeMapKey is a key of map, path segment is "{key}".
+	TypeMapKey
+
+	// TypeMapValue is a value of map, path segment is "[]".
+	TypeMapValue
+
+	// TypePointer is a target of pointer, path is not changed.
+	TypePointer
+
+	// TypeIn is a function parameter, path segment is "in[i]".
+	TypeIn
+
+	// TypeOut is a function result, path segment is "out[i]".
+	TypeOut
+)
+
+// TypeNode is a visited type.
+type TypeNode struct {
+	// Type is a visited type.
+	Type reflect.Type
+
+	// Path is a location of the node relative to the walked type.
+	Path FieldPath
+
+	// Step describes how the node is reached from its parent.
+	Step TypeStep
+
+	// Field is set for TypeField step.
+	Field *reflect.StructField
+
+	// Index is a position of a struct field or function parameter or result.
+	Index int
+
+	// Revisit is true if the type is already being walked by one of the parents, so the type is recursive.
+	// Children of revisited nodes are not walked.
+	Revisit bool
+}
+
+// TypeVisitor is called for each type node, children of the node are skipped if it returns false.
+type TypeVisitor func(n TypeNode) bool
+
+// WalkType walks type nodes depth-first without creating values.
+//
+// Struct fields (including unexported), elements of slices, arrays and channels, map keys and values,
+// pointer targets, function parameters and results are visited.
+// Recursive types are visited once more with Revisit flag at the place of recursion.
+func WalkType(t reflect.Type, visitor TypeVisitor) {
+	if t == nil {
+		return
+	}
+
+	w := typeWalker{visitor: visitor, walking: map[reflect.Type]bool{}}
+	w.walk(TypeNode{Type: t, Step: TypeRoot})
+}
+
+type typeWalker struct {
+	visitor TypeVisitor
+	walking map[reflect.Type]bool
+}
+
+func (w typeWalker) walk(n TypeNode) {
+	t := n.Type
+	n.Revisit = w.walking[t]
+
+	if !w.visitor(n) || n.Revisit {
+		return
+	}
+
+	w.walking[t] = true
+	defer delete(w.walking, t)
+
+	switch t.Kind() { //nolint:exhaustive // Other kinds have no children.
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			w.walk(TypeNode{Type: sf.Type, Path: n.Path.Field(sf.Name), Step: TypeField, Field: &sf, Index: i})
+		}
+	case reflect.Slice, reflect.Array, reflect.Chan:
+		w.walk(TypeNode{Type: t.Elem(), Path: n.Path + "[]", Step: TypeElem})
+	case reflect.Map:
+		w.walk(TypeNode{Type: t.Key(), Path: n.Path + "{key}", Step: TypeMapKey})
+		w.walk(TypeNode{Type: t.Elem(), Path: n.Path + "[]", Step: TypeMapValue})
+	case reflect.Ptr:
+		w.walk(TypeNode{Type: t.Elem(), Path: n.Path, Step: TypePointer})
+	case reflect.Func:
+		for i := 0; i < t.NumIn(); i++ {
+			w.walk(TypeNode{Type: t.In(i), Path: n.Path.Field("in").Index(i), Step: TypeIn, Index: i})
+		}
+
+		for i := 0; i < t.NumOut(); i++ {
+			w.walk(TypeNode{Type: t.Out(i), Path: n.Path.Field("out").Index(i), Step: TypeOut, Index: i})
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package refl_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/swaggest/refl"
+)
+
+type walkTypeNode struct {
+	Name     string
+	Children []*walkTypeNode
+	Labels   map[string]int
+	Handler  func(ctx string, n *walkTypeNode) error
+	private  [2]bool
+}
+
+func TestWalkType(t *testing.T) {
+	var visited []string
+
+	refl.WalkType(reflect.TypeOf(walkTypeNode{}), func(n refl.TypeNode) bool {
+		s := fmt.Sprintf("%s %s %d", n.Path, n.Type, n.Step)
+		if n.Revisit {
+			s += " revisit"
+		}
+
+		visited = append(visited, s)
+
+		return true
+	})
+
+	assert.Equal(t, []string{
+		" refl_test.walkTypeNode 0",
+		"Name string 1",
+		"Children []*refl_test.walkTypeNode 1",
+		"Children[] *refl_test.walkTypeNode 2",
+		"Children[] refl_test.walkTypeNode 5 revisit",
+		"Labels map[string]int 1",
+		"Labels{key} string 3",
+		"Labels[] int 4",
+		"Handler func(string, *refl_test.walkTypeNode) error 1",
+		"Handler.in[0] string 6",
+		"Handler.in[1] *refl_test.walkTypeNode 6",
+		"Handler.in[1] refl_test.walkTypeNode 5 revisit",
+		"Handler.out[0] error 7",
+		"private [2]bool 1",
+		"private[] bool 2",
+	}, visited)
+}
+
+func TestWalkType_skip(t *testing.T) {
+	var fields []string
+
+	refl.WalkType(reflect.TypeOf(walkTypeNode{}), func(n refl.TypeNode) bool {
+		if n.Step == refl.TypeField {
+			fields = append(fields, n.Field.Name)
+		}
+
+		return n.Step == refl.TypeRoot
+	})
+
+	assert.Equal(t, []string{"Name", "Children", "Labels", "Handler", "private"}, fields)
+}